@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/saxypandabear/wordlego/words"
+)
+
+// SpoilerPolicy decides who gets to see the result of a finished game
+type SpoilerPolicy string
+
+const (
+	// Results are shared with the channel. This is the default.
+	Public SpoilerPolicy = "public"
+	// Results are only shown to the player.
+	Private SpoilerPolicy = "private"
+)
+
+// DefaultMaxGuesses is the same as in the original Wordle
+const DefaultMaxGuesses = 6
+
+// MaxMaxGuesses is the highest maximum number of guesses that a game can have. With any
+// more, an Octordle of eight letter words doesn't fit in a Discord message.
+const MaxMaxGuesses = 8
+
+// GuildConfig is the configuration for a single guild. Direct messages use the
+// default configuration, see Default.
+type GuildConfig struct {
+	MaxGuesses      int           // default maximum number of guesses for games started in the guild
+	HardMode        bool          // whether games started in the guild default to hard mode
+	AnnounceChannel string        // ID of the channel to announce the daily puzzle in. empty to not announce
+	Spoilers        SpoilerPolicy // who gets to see the result of a finished game
+	MinPuzzle       int           // the lowest puzzle number that can be played. 0 for no limit
+	MaxPuzzle       int           // the highest puzzle number that can be played. 0 for no limit
+	Language        string        // code of the language that games started in the guild default to. empty for English
+	CoopTakeTurns   bool          // whether members of coop games default to taking turns, so nobody guesses twice in a row
+}
+
+// Default returns the configuration for a guild that hasn't configured anything.
+func Default() GuildConfig {
+	return GuildConfig{
+		MaxGuesses: DefaultMaxGuesses,
+		Spoilers:   Public,
+		Language:   words.DefaultLanguage,
+	}
+}
+
+// AllowsPuzzle returns whether the puzzle can be played in the guild, given the
+// puzzle of the day. The puzzle of the day can always be played, so the range only
+// restricts replaying other puzzles.
+func (c GuildConfig) AllowsPuzzle(puzzle, today int) bool {
+	if puzzle == today {
+		return true
+	}
+	if c.MinPuzzle > 0 && puzzle < c.MinPuzzle {
+		return false
+	}
+	if c.MaxPuzzle > 0 && puzzle > c.MaxPuzzle {
+		return false
+	}
+	return true
+}
+
+// Validate checks that the configuration makes sense, and returns an error that
+// describes the first problem with it.
+func (c GuildConfig) Validate() error {
+	if c.MaxGuesses < 1 {
+		return fmt.Errorf("the maximum number of guesses must be at least 1, not %d", c.MaxGuesses)
+	}
+	if c.MaxGuesses > MaxMaxGuesses {
+		return fmt.Errorf("the maximum number of guesses can't be more than %d, not %d", MaxMaxGuesses, c.MaxGuesses)
+	}
+	if c.Spoilers != Public && c.Spoilers != Private {
+		return fmt.Errorf("'%s' is not a valid spoiler policy", c.Spoilers)
+	}
+	if _, err := words.GetLanguage(c.Language); err != nil {
+		return err
+	}
+	if c.MinPuzzle < 0 || c.MaxPuzzle < 0 {
+		return fmt.Errorf("puzzle numbers can't be negative")
+	}
+	if c.MinPuzzle > 0 && c.MaxPuzzle > 0 && c.MinPuzzle > c.MaxPuzzle {
+		return fmt.Errorf("the minimum puzzle %d is after the maximum puzzle %d", c.MinPuzzle, c.MaxPuzzle)
+	}
+	return nil
+}
+
+// Format returns a string that shows the configuration, to display in Discord.
+func (c GuildConfig) Format() string {
+	channel := "none"
+	if c.AnnounceChannel != "" {
+		channel = fmt.Sprintf("<#%s>", c.AnnounceChannel)
+	}
+	puzzles := "any"
+	switch {
+	case c.MinPuzzle > 0 && c.MaxPuzzle > 0:
+		puzzles = fmt.Sprintf("%d to %d", c.MinPuzzle, c.MaxPuzzle)
+	case c.MinPuzzle > 0:
+		puzzles = fmt.Sprintf("%d and up", c.MinPuzzle)
+	case c.MaxPuzzle > 0:
+		puzzles = fmt.Sprintf("up to %d", c.MaxPuzzle)
+	}
+	lang, err := words.GetLanguage(c.Language)
+	if err != nil {
+		lang.Name = c.Language
+	}
+	return fmt.Sprintf("Default max guesses: %d\nDefault hard mode: %t\nDefault language: %s\nCoop members take turns: %t\nAnnouncement channel: %s\nResults: %s\nAllowed puzzles: %s (plus the daily puzzle)",
+		c.MaxGuesses, c.HardMode, lang.Name, c.CoopTakeTurns, channel, c.Spoilers, puzzles)
+}
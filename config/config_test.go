@@ -0,0 +1,73 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefault(t *testing.T) {
+	c := Default()
+	assert.Equal(t, DefaultMaxGuesses, c.MaxGuesses)
+	assert.False(t, c.HardMode)
+	assert.Equal(t, Public, c.Spoilers)
+	assert.Equal(t, "en", c.Language)
+	assert.NoError(t, c.Validate())
+}
+
+func TestAllowsPuzzle(t *testing.T) {
+	c := Default()
+	assert.True(t, c.AllowsPuzzle(1, 200))
+	assert.True(t, c.AllowsPuzzle(500, 200))
+
+	c.MinPuzzle = 100
+	c.MaxPuzzle = 150
+	assert.False(t, c.AllowsPuzzle(99, 200))
+	assert.True(t, c.AllowsPuzzle(100, 200))
+	assert.True(t, c.AllowsPuzzle(150, 200))
+	assert.False(t, c.AllowsPuzzle(151, 200))
+	// the daily puzzle can always be played
+	assert.True(t, c.AllowsPuzzle(200, 200))
+
+	c.MaxPuzzle = 0
+	assert.True(t, c.AllowsPuzzle(1000, 200))
+}
+
+func TestValidate(t *testing.T) {
+	c := Default()
+	c.MaxGuesses = 0
+	assert.EqualError(t, c.Validate(), "the maximum number of guesses must be at least 1, not 0")
+	c.MaxGuesses = 9
+	assert.EqualError(t, c.Validate(), "the maximum number of guesses can't be more than 8, not 9")
+
+	c = Default()
+	c.Spoilers = "secret"
+	assert.EqualError(t, c.Validate(), "'secret' is not a valid spoiler policy")
+
+	c = Default()
+	c.Language = "xx"
+	assert.EqualError(t, c.Validate(), "'xx' is not a supported language")
+	// configurations from before languages could be configured are in English
+	c.Language = ""
+	assert.NoError(t, c.Validate())
+
+	c = Default()
+	c.MinPuzzle = -1
+	assert.EqualError(t, c.Validate(), "puzzle numbers can't be negative")
+
+	c = Default()
+	c.MinPuzzle = 10
+	c.MaxPuzzle = 5
+	assert.EqualError(t, c.Validate(), "the minimum puzzle 10 is after the maximum puzzle 5")
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "Default max guesses: 6\nDefault hard mode: false\nDefault language: English\nCoop members take turns: false\nAnnouncement channel: none\nResults: public\nAllowed puzzles: any (plus the daily puzzle)", Default().Format())
+
+	c := GuildConfig{MaxGuesses: 8, HardMode: true, AnnounceChannel: "123", Spoilers: Private, MinPuzzle: 10, MaxPuzzle: 20, Language: "de", CoopTakeTurns: true}
+	assert.Equal(t, "Default max guesses: 8\nDefault hard mode: true\nDefault language: Deutsch\nCoop members take turns: true\nAnnouncement channel: <#123>\nResults: private\nAllowed puzzles: 10 to 20 (plus the daily puzzle)", c.Format())
+	c.MaxPuzzle = 0
+	assert.Contains(t, c.Format(), "Allowed puzzles: 10 and up")
+	c.MinPuzzle, c.MaxPuzzle = 0, 20
+	assert.Contains(t, c.Format(), "Allowed puzzles: up to 20")
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"sync"
+
+	"github.com/saxypandabear/wordlego/internal/jsonfile"
+)
+
+// Store keeps track of the configuration of each guild. Implementations must be
+// safe to use from multiple goroutines.
+type Store interface {
+	// Get returns the guild's configuration, or the default configuration if
+	// the guild hasn't configured anything
+	Get(guild string) GuildConfig
+	// Put replaces the guild's configuration
+	Put(guild string, c GuildConfig) error
+	// List returns the configuration of every guild that has configured something
+	List() map[string]GuildConfig
+}
+
+// MemoryStore is a Store that only keeps the configuration in memory. All of the
+// configuration is lost when the bot restarts.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	configs map[string]GuildConfig
+}
+
+// NewMemoryStore creates an empty in-memory configuration store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		configs: make(map[string]GuildConfig),
+	}
+}
+
+func (m *MemoryStore) Get(guild string) GuildConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if c, ok := m.configs[guild]; ok {
+		return c
+	}
+	return Default()
+}
+
+func (m *MemoryStore) Put(guild string, c GuildConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.configs[guild] = c
+	return nil
+}
+
+func (m *MemoryStore) List() map[string]GuildConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return copyConfigs(m.configs)
+}
+
+// FileStore is a Store that writes the configuration to a JSON file on disk
+// every time it changes, so that it survives the bot restarting. The configuration
+// is also kept in memory, so reads never touch the disk.
+type FileStore struct {
+	mu      sync.RWMutex
+	path    string
+	configs map[string]GuildConfig
+}
+
+// NewFileStore creates a configuration store that is backed by the JSON file at
+// the given path. If the file already exists, the configuration in it is loaded into
+// the store. If it does not exist, it is created on the first write.
+func NewFileStore(path string) (*FileStore, error) {
+	fs := FileStore{
+		path:    path,
+		configs: make(map[string]GuildConfig),
+	}
+	if err := jsonfile.Load(path, &fs.configs); err != nil {
+		return nil, err
+	}
+	return &fs, nil
+}
+
+func (f *FileStore) Get(guild string) GuildConfig {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if c, ok := f.configs[guild]; ok {
+		return c
+	}
+	return Default()
+}
+
+func (f *FileStore) Put(guild string, c GuildConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	previous, existed := f.configs[guild]
+	f.configs[guild] = c
+	if err := jsonfile.Save(f.path, f.configs); err != nil {
+		// keep the memory in sync with the file
+		if existed {
+			f.configs[guild] = previous
+		} else {
+			delete(f.configs, guild)
+		}
+		return err
+	}
+	return nil
+}
+
+func (f *FileStore) List() map[string]GuildConfig {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return copyConfigs(f.configs)
+}
+
+func copyConfigs(configs map[string]GuildConfig) map[string]GuildConfig {
+	list := make(map[string]GuildConfig, len(configs))
+	for guild, c := range configs {
+		list[guild] = c
+	}
+	return list
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "config.json"))
+	assert.NoError(t, err)
+	testStore(t, store)
+}
+
+func TestFileStoreSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	store, err := NewFileStore(path)
+	assert.NoError(t, err)
+	c := GuildConfig{MaxGuesses: 8, HardMode: true, AnnounceChannel: "123", Spoilers: Private, MinPuzzle: 10}
+	assert.NoError(t, store.Put("guild", c))
+
+	restarted, err := NewFileStore(path)
+	assert.NoError(t, err)
+	assert.Equal(t, c, restarted.Get("guild"))
+}
+
+func TestFileStoreFailedWrite(t *testing.T) {
+	// the parent directory doesn't exist, so the file can't be written
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "missing", "config.json"))
+	assert.NoError(t, err)
+	assert.Error(t, store.Put("guild", GuildConfig{MaxGuesses: 8}))
+	assert.Equal(t, Default(), store.Get("guild"))
+	assert.Empty(t, store.List())
+}
+
+func testStore(t *testing.T, store Store) {
+	assert.Equal(t, Default(), store.Get("guild"))
+	assert.Empty(t, store.List())
+
+	c := Default()
+	c.HardMode = true
+	assert.NoError(t, store.Put("guild", c))
+	assert.Equal(t, c, store.Get("guild"))
+	assert.Equal(t, Default(), store.Get("other-guild"))
+	assert.Equal(t, map[string]GuildConfig{"guild": c}, store.List())
+
+	// modifying the listed configuration shouldn't affect the store
+	delete(store.List(), "guild")
+	assert.Len(t, store.List(), 1)
+}
@@ -0,0 +1,93 @@
+package game
+
+import (
+	"log"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/saxypandabear/wordlego/config"
+)
+
+// names of the subcommand and options for the wordle-admin command. The max-guesses
+// and hard-mode options reuse the names of the wordle command's options.
+const (
+	ConfigSubcommand      = "config"
+	AnnounceChannelOption = "announce-channel"
+	AnnounceOption        = "announce"
+	SpoilersOption        = "spoilers"
+	MinPuzzleOption       = "min-puzzle"
+	MaxPuzzleOption       = "max-puzzle"
+)
+
+// keep track of each guild's configuration. defaults to keeping it in memory,
+// see UseConfigStore to persist it.
+var configs config.Store = config.NewMemoryStore()
+
+// UseConfigStore replaces the store that keeps track of each guild's configuration.
+// This should be called before the bot starts handling interactions.
+func UseConfigStore(store config.Store) {
+	configs = store
+}
+
+// Admin is the hook for the bot to execute the administrative commands, which
+// configure how Wordle is played in a guild. Discord hides the command from members
+// without the Manage Server permission, but the permission is checked here too, since
+// guilds can override who is allowed to use a command.
+func Admin(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Member == nil || i.GuildID == "" {
+		respondEphemeral(s, i, "Wordle can only be configured in a server.")
+		return
+	}
+	if i.Member.Permissions&discordgo.PermissionManageServer == 0 {
+		respondEphemeral(s, i, "You need the Manage Server permission to configure Wordle.")
+		return
+	}
+
+	data := i.ApplicationCommandData()
+	if len(data.Options) == 0 || data.Options[0].Name != ConfigSubcommand {
+		respondEphemeral(s, i, "Invalid subcommand")
+		return
+	}
+
+	cfg := applyConfigOptions(configs.Get(i.GuildID), data.Options[0].Options)
+	if err := cfg.Validate(); err != nil {
+		respondEphemeral(s, i, err.Error())
+		return
+	}
+	if err := configs.Put(i.GuildID, cfg); err != nil {
+		log.Printf("Exception occurred when trying to save the guild configuration: %s\n", err.Error())
+		respondEphemeral(s, i, "An error occurred when trying to save the configuration. Contact the bot owner.")
+		return
+	}
+	respondEphemeral(s, i, "Wordle configuration for this server:\n"+cfg.Format())
+}
+
+// applyConfigOptions returns the configuration with the options that the admin
+// filled in applied on top of it. Any options that weren't filled in are left as-is,
+// so running the command without any options just shows the configuration.
+func applyConfigOptions(cfg config.GuildConfig, opts []*discordgo.ApplicationCommandInteractionDataOption) config.GuildConfig {
+	for _, opt := range opts {
+		switch opt.Name {
+		case MaxGuessesOption:
+			cfg.MaxGuesses = int(opt.IntValue())
+		case HardModeOption:
+			cfg.HardMode = opt.BoolValue()
+		case AnnounceChannelOption:
+			cfg.AnnounceChannel = opt.ChannelValue(nil).ID
+		case AnnounceOption:
+			if !opt.BoolValue() {
+				cfg.AnnounceChannel = ""
+			}
+		case SpoilersOption:
+			cfg.Spoilers = config.SpoilerPolicy(opt.StringValue())
+		case MinPuzzleOption:
+			cfg.MinPuzzle = int(opt.IntValue())
+		case MaxPuzzleOption:
+			cfg.MaxPuzzle = int(opt.IntValue())
+		case LanguageOption:
+			cfg.Language = opt.StringValue()
+		case TakeTurnsOption:
+			cfg.CoopTakeTurns = opt.BoolValue()
+		}
+	}
+	return cfg
+}
@@ -0,0 +1,59 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/saxypandabear/wordlego/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyConfigOptions(t *testing.T) {
+	// no options just shows the configuration
+	assert.Equal(t, config.Default(), applyConfigOptions(config.Default(), nil))
+
+	cfg := applyConfigOptions(config.Default(), []*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: MaxGuessesOption, Type: discordgo.ApplicationCommandOptionInteger, Value: float64(8)},
+		{Name: HardModeOption, Type: discordgo.ApplicationCommandOptionBoolean, Value: true},
+		{Name: AnnounceChannelOption, Type: discordgo.ApplicationCommandOptionChannel, Value: "123"},
+		{Name: SpoilersOption, Type: discordgo.ApplicationCommandOptionString, Value: "private"},
+		{Name: MinPuzzleOption, Type: discordgo.ApplicationCommandOptionInteger, Value: float64(10)},
+		{Name: MaxPuzzleOption, Type: discordgo.ApplicationCommandOptionInteger, Value: float64(20)},
+		{Name: LanguageOption, Type: discordgo.ApplicationCommandOptionString, Value: "es"},
+		{Name: TakeTurnsOption, Type: discordgo.ApplicationCommandOptionBoolean, Value: true},
+	})
+	assert.Equal(t, config.GuildConfig{
+		MaxGuesses:      8,
+		HardMode:        true,
+		AnnounceChannel: "123",
+		Spoilers:        config.Private,
+		MinPuzzle:       10,
+		MaxPuzzle:       20,
+		Language:        "es",
+		CoopTakeTurns:   true,
+	}, cfg)
+
+	// options that aren't provided are left alone
+	cfg = applyConfigOptions(cfg, []*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: AnnounceOption, Type: discordgo.ApplicationCommandOptionBoolean, Value: false},
+		{Name: MaxPuzzleOption, Type: discordgo.ApplicationCommandOptionInteger, Value: float64(0)},
+	})
+	assert.Equal(t, "", cfg.AnnounceChannel)
+	assert.Equal(t, 0, cfg.MaxPuzzle)
+	assert.Equal(t, 10, cfg.MinPuzzle)
+	assert.Equal(t, 8, cfg.MaxGuesses)
+}
+
+func TestResultFlags(t *testing.T) {
+	store := config.NewMemoryStore()
+	UseConfigStore(store)
+	defer UseConfigStore(config.NewMemoryStore())
+
+	i := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{GuildID: "guild"}}
+	assert.Equal(t, discordgo.MessageFlags(0), resultFlags(i))
+
+	cfg := config.Default()
+	cfg.Spoilers = config.Private
+	assert.NoError(t, store.Put("guild", cfg))
+	assert.Equal(t, discordgo.MessageFlags(1<<6), resultFlags(i))
+}
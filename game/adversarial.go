@@ -0,0 +1,136 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/saxypandabear/wordlego/guess"
+	"github.com/saxypandabear/wordlego/words"
+)
+
+// errAbsurdleCode is returned when an adversarial game is started with the code of a
+// custom puzzle, which has a fixed word.
+var errAbsurdleCode = errors.New("Absurdle games don't have a fixed word, so they can't be played with a code.")
+
+// newAdversarialSession creates a session for an adversarial game, where the solution
+// isn't picked until it has to be. Every word in the language's list of solutions of the
+// given length starts out as a candidate, see dodge.
+func newAdversarialSession(language string, length, allowedGuesses int) (*WordleSession, error) {
+	sols, err := words.SolutionsFor(language, length)
+	if err != nil {
+		return nil, err
+	}
+	if len(sols) == 0 {
+		return nil, fmt.Errorf("there are no %d letter solutions in '%s'", length, language)
+	}
+	ws := NewSession(sols[0], allowedGuesses, 0)
+	ws.Language = language
+	ws.Adversarial = true
+	ws.Candidates = append([]string(nil), sols...)
+	return ws, nil
+}
+
+// dodge narrows down the candidates of an adversarial session for the guess, by keeping
+// the ones that would give the guess the same feedback as the most other candidates. On a
+// tie, the feedback that reveals the least is kept, so the guess gets as little information
+// as possible. The solution becomes one of the remaining candidates, which all give the
+// same feedback, so the guess is scored exactly like it would be against any of them. The
+// player can only solve the puzzle once their guess is the last candidate left.
+func (ws *WordleSession) dodge(word string) {
+	buckets := make(map[string][]string)
+	for _, c := range ws.Candidates {
+		key := pattern(word, c)
+		buckets[key] = append(buckets[key], c)
+	}
+	var best string
+	for key, bucket := range buckets {
+		if best == "" || isWorseFeedback(key, bucket, best, buckets[best]) {
+			best = key
+		}
+	}
+	ws.Candidates = buckets[best]
+	ws.Solution = ws.Candidates[0]
+}
+
+// isWorseFeedback returns whether the feedback with the key leaves the player worse off
+// than the other feedback: it has more candidates, or as many candidates but fewer letters
+// revealed. Keys that are still tied are compared directly, to always pick the same one.
+func isWorseFeedback(key string, bucket []string, otherKey string, other []string) bool {
+	if len(bucket) != len(other) {
+		return len(bucket) > len(other)
+	}
+	if score, otherScore := revealed(key), revealed(otherKey); score != otherScore {
+		return score < otherScore
+	}
+	return key < otherKey
+}
+
+// revealed scores how much the feedback with the key reveals, where a correct letter
+// counts for more than a letter in the wrong position.
+func revealed(key string) int {
+	score := 0
+	for i := 0; i < len(key); i++ {
+		score += int(key[i])
+	}
+	return score
+}
+
+// pattern returns the feedback for the word against the candidate as a key, with one
+// byte for the guess.Correctness of each letter.
+func pattern(word, candidate string) string {
+	g, err := guess.ConvertToGuess(word, candidate)
+	if err != nil {
+		return ""
+	}
+	return feedbackKey(g)
+}
+
+// restoreCandidates rebuilds the candidates of an adversarial session from its guesses,
+// since only the guesses are persisted. The candidates are the solutions that would have
+// given every guess the same feedback that it got, see consistentSolutions.
+func (ws *WordleSession) restoreCandidates() {
+	sols, _ := words.SolutionsFor(ws.Language, ws.length())
+	ws.Candidates = ws.consistentSolutions(sols)
+	if len(ws.Candidates) == 0 {
+		// the word lists changed since the session was saved, so stick with its solution
+		ws.Candidates = []string{ws.Solution}
+	}
+}
+
+// consistentSolutions returns the words that could still be the solution, given what
+// the session has revealed so far: they would have given every guess the same feedback
+// that it got, and they have the letters that hints revealed in the same positions.
+func (ws *WordleSession) consistentSolutions(sols []string) []string {
+	var consistent []string
+	for _, c := range sols {
+		if ws.isConsistent(c) {
+			consistent = append(consistent, c)
+		}
+	}
+	return consistent
+}
+
+// isConsistent returns whether the word could be the solution, see consistentSolutions.
+func (ws *WordleSession) isConsistent(word string) bool {
+	for i, g := range ws.Guesses {
+		if pattern(ws.Attempts[i], word) != feedbackKey(g) {
+			return false
+		}
+	}
+	runes := []rune(word)
+	for _, h := range ws.Hints {
+		if h.Kind == LetterHint && (h.Position >= len(runes) || string(runes[h.Position]) != h.Letter) {
+			return false
+		}
+	}
+	return true
+}
+
+// feedbackKey returns the key of the feedback that the guess got, like pattern.
+func feedbackKey(g *guess.Guess) string {
+	key := make([]byte, len(g.Letters))
+	for i, l := range g.Letters {
+		key[i] = byte(l.Correctness)
+	}
+	return string(key)
+}
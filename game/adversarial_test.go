@@ -0,0 +1,89 @@
+package game
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/saxypandabear/wordlego/guess"
+	"github.com/saxypandabear/wordlego/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDodge(t *testing.T) {
+	ws := NewSession("party", allowedGuesses, 0)
+	ws.Adversarial = true
+	ws.Candidates = []string{"party", "pants", "beams", "crane", "moist"}
+
+	// nothing in common with beams, crane and moist is the biggest group
+	assert.NoError(t, ws.Guess("pudgy"))
+	assert.Equal(t, []string{"beams", "crane", "moist"}, ws.Candidates)
+	assert.Equal(t, "beams", ws.Solution)
+	assert.False(t, ws.IsSolved())
+
+	// every candidate is in its own group, so the one that reveals the least wins
+	assert.NoError(t, ws.Guess("crane"))
+	assert.Equal(t, []string{"moist"}, ws.Candidates)
+
+	// the last candidate can't get away
+	assert.NoError(t, ws.Guess("moist"))
+	assert.True(t, ws.IsSolved())
+}
+
+func TestAdversarialSession(t *testing.T) {
+	ws, err := newAdversarialSession(words.DefaultLanguage, words.DefaultLength, allowedGuesses)
+	assert.NoError(t, err)
+	assert.Len(t, ws.Candidates, len(words.Solutions))
+	assert.True(t, strings.HasPrefix(ws.PrintGame(true), "```ansi\nAbsurdle: 0/6\n"))
+
+	assert.NoError(t, ws.Guess("crane"))
+	assert.False(t, ws.IsSolved())
+	assert.Less(t, len(ws.Candidates), len(words.Solutions))
+	// every candidate that's left would have given the same feedback
+	key := feedbackKey(ws.Guesses[0])
+	for _, c := range ws.Candidates {
+		assert.Equal(t, key, pattern("crane", c))
+	}
+	assert.Contains(t, ws.Candidates, ws.Solution)
+	assert.False(t, ws.Result("player", ws.Started).Daily)
+
+	// the candidates aren't saved, but are rebuilt from the guesses
+	data, err := json.Marshal(ws)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "Candidates")
+	var restored WordleSession
+	assert.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, ws.Candidates, restored.Candidates)
+
+	_, err = newAdversarialSession(words.Spanish.Code, 4, allowedGuesses)
+	assert.Error(t, err)
+}
+
+func TestStartAdversarialSession(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	args := &CommandArgs{PuzzleNum: 1, MaxGuesses: 10, Length: 6, Language: words.DefaultLanguage, Mode: Absurdle, HardMode: true}
+	sess, err := startSession("player", "player", args)
+	assert.NoError(t, err)
+	assert.True(t, sess.Adversarial)
+	assert.True(t, sess.HardMode)
+	assert.Equal(t, 0, sess.Puzzle)
+	assert.Equal(t, 10, sess.MaxAllowedGuesses)
+	assert.Equal(t, "Absurdle (6 letters)", sess.title())
+
+	sess, err = guessSession("player", "player", "garden")
+	assert.NoError(t, err)
+	assert.Len(t, sess.Guesses, 1)
+	saved, ok := sessions.Get("player")
+	assert.True(t, ok)
+	assert.Equal(t, sess.Candidates, saved.Candidates)
+	for _, l := range sess.Guesses[0].Letters {
+		assert.NotEqual(t, guess.Unknown, l.Correctness)
+	}
+}
+
+func BenchmarkDodge(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ws, _ := newAdversarialSession(words.DefaultLanguage, words.DefaultLength, allowedGuesses)
+		ws.dodge("crane")
+	}
+}
@@ -0,0 +1,172 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/saxypandabear/wordlego/puzzles"
+	"github.com/saxypandabear/wordlego/solver"
+	"github.com/saxypandabear/wordlego/words"
+)
+
+// AnalyzeButtonID is the custom ID of the button that analyzes a finished game. The
+// session is gone by the time that the button is clicked, so the game is kept in the
+// analyses, and the ID only has its token after a colon. The guesses are never in the
+// ID itself, since every client that can see the result message gets its custom IDs.
+const AnalyzeButtonID = "wordle-analyze"
+
+// the number of finished games that are kept for analysis, after which the oldest
+// ones are forgotten and their buttons stop working
+const maxAnalyses = 10000
+
+// analysis is a finished game that can be analyzed, see AnalyzeButton.
+type analysis struct {
+	Title    string   // the title of the game, which doesn't give away the puzzle of a race, see title
+	Solution string   // the word that the game was for
+	Guesses  []string // the guesses of the game, in order
+}
+
+// keep track of the finished games that can be analyzed
+var analyses = newAnalysisRegistry()
+
+// analysisRegistry keeps the finished games that can be analyzed in memory, keyed by
+// a random token, so a restart only costs the buttons of the games before it.
+type analysisRegistry struct {
+	mu     sync.Mutex
+	games  map[string]analysis
+	tokens []string // the tokens in the order that they were added, to forget the oldest first
+}
+
+func newAnalysisRegistry() *analysisRegistry {
+	return &analysisRegistry{
+		games: make(map[string]analysis),
+	}
+}
+
+// add keeps the game, and returns the token that it's kept under.
+func (r *analysisRegistry) add(a analysis) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token, err := puzzles.NewCode()
+	for err == nil && r.games[token].Solution != "" {
+		token, err = puzzles.NewCode()
+	}
+	if err != nil {
+		return "", err
+	}
+	r.games[token] = a
+	r.tokens = append(r.tokens, token)
+	if len(r.tokens) > maxAnalyses {
+		delete(r.games, r.tokens[0])
+		r.tokens = r.tokens[1:]
+	}
+	return token, nil
+}
+
+func (r *analysisRegistry) get(token string) (analysis, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.games[token]
+	return a, ok
+}
+
+// canAnalyze returns whether the session's game can be analyzed once it's over. The
+// solver only knows the usual five letter English words, so that's all it can analyze,
+// and only for single puzzles whose word comes from the puzzle number.
+func canAnalyze(sess *WordleSession) bool {
+	return sess.Language == words.DefaultLanguage &&
+		sess.length() == words.DefaultLength &&
+		len(sess.Boards) == 0 &&
+		!sess.Adversarial &&
+		sess.Custom == "" &&
+		sess.Puzzle > 0 &&
+		len(sess.Attempts) > 0
+}
+
+// offerAnalysis returns whether the result of the session should have an Analyze button.
+// A result that the whole channel sees only gets one once nobody else can still be
+// playing the puzzle, so never for a race, and only for puzzles from before today.
+func offerAnalysis(sess *WordleSession, private bool, now time.Time) bool {
+	if !canAnalyze(sess) {
+		return false
+	}
+	return private || (sess.Match == "" && sess.Puzzle < words.DetermineWordForDay(now))
+}
+
+// analyzeID keeps the session's game for analysis, and returns the custom ID of the
+// button that analyzes it.
+func analyzeID(sess *WordleSession) (string, error) {
+	token, err := analyses.add(analysis{
+		Title:    sess.title(),
+		Solution: sess.Solution,
+		Guesses:  append([]string(nil), sess.Attempts...),
+	})
+	if err != nil {
+		return "", err
+	}
+	return AnalyzeButtonID + ":" + token, nil
+}
+
+// parseAnalyzeID looks up the game that the custom ID of the button is for, see analyzeID.
+func parseAnalyzeID(id string) (analysis, bool) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 || parts[0] != AnalyzeButtonID {
+		return analysis{}, false
+	}
+	return analyses.get(parts[1])
+}
+
+// analyze replays the guesses of the game with the solver, and formats how each of them
+// went. The words are behind spoiler tags, since whoever clicks the button might not
+// have played the puzzle yet.
+func analyze(s *solver.Solver, a analysis) (string, error) {
+	steps, err := s.Analyze(a.Guesses, a.Solution)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Analysis of %s\n", a.Title)
+	for _, st := range steps {
+		fmt.Fprintf(&sb, "||%s||: %d → %d words, %.2f bits (best: ||%s||, %.2f bits)\n",
+			strings.ToUpper(st.Guess), st.Before, st.After, st.Bits, strings.ToUpper(st.Best), st.BestBits)
+	}
+	return sb.String(), nil
+}
+
+// AnalyzeButton is the hook for the Analyze button on the result of a finished game.
+// Building the solver can take a while the first time, so the response is deferred,
+// and the analysis is only shown to whoever clicked the button.
+func AnalyzeButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	a, ok := parseAnalyzeID(i.MessageComponentData().CustomID)
+	if !ok {
+		respondEphemeral(s, i, "This button doesn't work anymore.")
+		return
+	}
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: 1 << 6,
+		},
+	})
+	if err != nil {
+		log.Printf("Exception occurred when trying to defer the analysis: %s\n", err.Error())
+		return
+	}
+
+	content, err := analyze(solver.Default(), a)
+	if errors.Is(err, solver.ErrUnknownSolution) {
+		content = "This puzzle's word isn't one that the solver knows, so the game can't be analyzed."
+	} else if err != nil {
+		log.Printf("Exception occurred when trying to analyze a game: %s\n", err.Error())
+		content = "An error occurred when trying to analyze the game. Contact the bot owner."
+	}
+	s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Flags:   1 << 6,
+		Content: content,
+	})
+}
@@ -0,0 +1,112 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/saxypandabear/wordlego/solver"
+	"github.com/saxypandabear/wordlego/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeID(t *testing.T) {
+	sess := NewSession("party", allowedGuesses, 42)
+	assert.NoError(t, sess.Guess("crane"))
+	assert.NoError(t, sess.Guess("party"))
+
+	id, err := analyzeID(sess)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(id, AnalyzeButtonID+":"))
+	// the guesses and the puzzle are kept on the server, not in the ID
+	assert.NotContains(t, id, "party")
+	assert.Len(t, id, len(AnalyzeButtonID+":")+6)
+	a, ok := parseAnalyzeID(id)
+	assert.True(t, ok)
+	assert.Equal(t, analysis{Title: "Wordle 42", Solution: "party", Guesses: []string{"crane", "party"}}, a)
+
+	_, ok = parseAnalyzeID(AnalyzeButtonID + ":NOPE23")
+	assert.False(t, ok)
+	_, ok = parseAnalyzeID(PracticeAgainButtonID + ":en:5:6:false:qwerty")
+	assert.False(t, ok)
+}
+
+func TestAnalysisRegistryForgetsOldest(t *testing.T) {
+	r := newAnalysisRegistry()
+	first, err := r.add(analysis{Solution: "party"})
+	assert.NoError(t, err)
+	for i := 0; i < maxAnalyses; i++ {
+		_, err = r.add(analysis{Solution: "beams"})
+		assert.NoError(t, err)
+	}
+	_, ok := r.get(first)
+	assert.False(t, ok)
+	assert.Len(t, r.games, maxAnalyses)
+}
+
+func TestCanAnalyze(t *testing.T) {
+	sess := NewSession("party", allowedGuesses, 42)
+	assert.False(t, canAnalyze(sess)) // nothing to analyze yet
+	assert.NoError(t, sess.Guess("party"))
+	assert.True(t, canAnalyze(sess))
+
+	sess.Custom = "ABC123"
+	assert.False(t, canAnalyze(sess))
+	sess.Custom = ""
+	sess.Language = words.Spanish.Code
+	assert.False(t, canAnalyze(sess))
+
+	multi := newMultiSession([]string{"party", "beams"}, words.DefaultLanguage, 7, puzzleNum)
+	assert.NoError(t, multi.Guess("party"))
+	assert.False(t, canAnalyze(multi))
+
+	absurdle, err := newAdversarialSession(words.DefaultLanguage, words.DefaultLength, allowedGuesses)
+	assert.NoError(t, err)
+	assert.NoError(t, absurdle.Guess("crane"))
+	assert.False(t, canAnalyze(absurdle))
+}
+
+func TestOfferAnalysis(t *testing.T) {
+	now := time.Now()
+	today := words.DetermineWordForDay(now)
+	daily := NewSession(words.Solutions[today-1], allowedGuesses, today)
+	assert.NoError(t, daily.Guess("crane"))
+	// a public result of today's puzzle would spoil it for the channel
+	assert.False(t, offerAnalysis(daily, false, now))
+	assert.True(t, offerAnalysis(daily, true, now))
+	assert.True(t, offerAnalysis(daily, false, now.AddDate(0, 0, 1)))
+	assert.Empty(t, resultComponents(daily, 0))
+	assert.NotEmpty(t, resultComponents(daily, 1<<6))
+
+	// nor is a race ever analyzed in public
+	race := NewSession(words.Solutions[0], allowedGuesses, 1)
+	race.Match = "match"
+	assert.NoError(t, race.Guess("crane"))
+	assert.False(t, offerAnalysis(race, false, now))
+	assert.True(t, offerAnalysis(race, true, now))
+
+	older := NewSession(words.Solutions[0], allowedGuesses, 1)
+	assert.NoError(t, older.Guess("crane"))
+	assert.True(t, offerAnalysis(older, false, now))
+	buttons := resultComponents(older, 0)[0].(discordgo.ActionsRow).Components
+	assert.True(t, strings.HasPrefix(buttons[0].(discordgo.Button).CustomID, AnalyzeButtonID+":"))
+}
+
+func TestAnalyze(t *testing.T) {
+	s, err := solver.New(words.Solutions[:20], nil)
+	assert.NoError(t, err)
+	solution := words.Solutions[0]
+
+	content, err := analyze(s, analysis{Title: "Wordle race", Solution: solution, Guesses: []string{words.Solutions[1], solution}})
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "Analysis of Wordle race", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "||"+strings.ToUpper(words.Solutions[1])+"||: 20 → "))
+	assert.Contains(t, lines[2], "||"+strings.ToUpper(solution)+"||")
+
+	// the solver doesn't know the word of a later puzzle
+	_, err = analyze(s, analysis{Solution: words.Solutions[30], Guesses: []string{solution}})
+	assert.ErrorIs(t, err, solver.ErrUnknownSolution)
+}
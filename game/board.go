@@ -0,0 +1,141 @@
+package game
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Discord only allows an interaction's token to be used for 15 minutes. The board
+// stops using a token a minute early, so that an edit doesn't race the expiration.
+const boardTokenLifetime = 14 * time.Minute
+
+// errBoardExpired is returned when the board's message can't be edited anymore
+var errBoardExpired = errors.New("the board's interaction token has expired")
+
+// Board identifies the Discord message that displays a game session, so that
+// the message can be edited in place as the game progresses, instead of posting
+// a new message for every guess. The board is an interaction response, which is
+// ephemeral unless the session is a coop session, see boardFlags. Either way, it
+// can only be edited with the token of the interaction that it belongs to.
+// Tokens expire, so the board follows the most recent interaction that displayed it.
+type Board struct {
+	AppID   string    // ID of the application that responded to the interaction
+	Token   string    // token of the interaction that displayed the board
+	Created time.Time // when the interaction that displayed the board was received
+}
+
+// newBoard anchors a board to the response of the given interaction.
+func newBoard(i *discordgo.Interaction, now time.Time) *Board {
+	return &Board{
+		AppID:   i.AppID,
+		Token:   i.Token,
+		Created: now,
+	}
+}
+
+// Editable returns whether the board's token can still be used to edit the message.
+func (b *Board) Editable(now time.Time) bool {
+	return b != nil && b.Token != "" && now.Sub(b.Created) < boardTokenLifetime
+}
+
+// interaction returns enough of the original interaction to edit its response.
+func (b *Board) interaction() *discordgo.Interaction {
+	return &discordgo.Interaction{
+		AppID: b.AppID,
+		Token: b.Token,
+	}
+}
+
+// boardMessage returns the content and the components of the board for the
+// session. The buttons are only attached while the session can still be played.
+func boardMessage(sess *WordleSession) (string, []discordgo.MessageComponent) {
+	components := []discordgo.MessageComponent{} // an empty list removes any existing buttons
+	if sess.CanPlay() {
+		components = boardComponents(sess)
+	}
+	return sess.PrintGame(false), components
+}
+
+// boardFlags returns the message flags for posting the session's board. The board is
+// only visible to the player, since it shows the letters that were guessed, unless the
+// session is shared with the channel.
+func boardFlags(sess *WordleSession) discordgo.MessageFlags {
+	if sess.Coop {
+		return 0
+	}
+	return 1 << 6
+}
+
+// editBoard edits the session's existing board message to show the current state
+// of the session. This returns an error if the board can't be edited anymore.
+func editBoard(s *discordgo.Session, sess *WordleSession) error {
+	if !sess.Board.Editable(time.Now()) {
+		return errBoardExpired
+	}
+	content, components := boardMessage(sess)
+	_, err := s.InteractionResponseEdit(sess.Board.interaction(), &discordgo.WebhookEdit{
+		Content:    &content,
+		Components: &components,
+	})
+	return err
+}
+
+// updateBoard displays the current state of the session in response to the
+// interaction. Where possible, the existing board message is updated in place:
+// 1. A guess submitted from the board's Guess button updates the message the
+// button is attached to, as the response to the interaction.
+// 2. Otherwise, the existing board is edited with the token it was created with,
+// without responding to the interaction.
+// 3. If that's not possible anymore, a new board is posted as the response to the
+// interaction.
+// The board is anchored to the interaction whenever it is used as the response, so
+// that the board can keep being edited. This returns whether or not the interaction
+// was responded to, and the caller is responsible for saving the session.
+func updateBoard(s *discordgo.Session, i *discordgo.InteractionCreate, sess *WordleSession) bool {
+	now := time.Now()
+	content, components := boardMessage(sess)
+	if i.Type == discordgo.InteractionModalSubmit && i.Message != nil {
+		err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseUpdateMessage,
+			Data: &discordgo.InteractionResponseData{
+				Content:    content,
+				Components: components,
+			},
+		})
+		if err == nil {
+			sess.Board = newBoard(i.Interaction, now)
+			return true
+		}
+	}
+	if err := editBoard(s, sess); err == nil {
+		return false
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:           boardFlags(sess),
+			Content:         content,
+			Components:      components,
+			AllowedMentions: &discordgo.MessageAllowedMentions{}, // don't ping the coop players
+		},
+	})
+	sess.Board = newBoard(i.Interaction, now)
+	return true
+}
+
+// acknowledge responds to the interaction without leaving a message behind, for
+// when the result of the interaction is already shown elsewhere, i.e. on the board.
+func acknowledge(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: 1 << 6,
+		},
+	})
+	if err == nil {
+		s.InteractionResponseDelete(i.Interaction)
+	}
+}
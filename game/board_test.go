@@ -0,0 +1,51 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBoard(t *testing.T) {
+	now := time.Date(2022, time.February, 1, 12, 0, 0, 0, time.UTC)
+	b := newBoard(&discordgo.Interaction{AppID: "app", Token: "token"}, now)
+	assert.Equal(t, &Board{AppID: "app", Token: "token", Created: now}, b)
+	assert.Equal(t, &discordgo.Interaction{AppID: "app", Token: "token"}, b.interaction())
+}
+
+func TestBoardEditable(t *testing.T) {
+	now := time.Date(2022, time.February, 1, 12, 0, 0, 0, time.UTC)
+	b := &Board{AppID: "app", Token: "token", Created: now}
+	assert.True(t, b.Editable(now))
+	assert.True(t, b.Editable(now.Add(10*time.Minute)))
+	assert.False(t, b.Editable(now.Add(boardTokenLifetime)))
+	assert.False(t, b.Editable(now.Add(time.Hour)))
+
+	var missing *Board
+	assert.False(t, missing.Editable(now))
+	assert.False(t, (&Board{Created: now}).Editable(now))
+}
+
+func TestBoardMessage(t *testing.T) {
+	ws := testSetup()
+	_ = ws.Guess("pants")
+	content, components := boardMessage(ws)
+	assert.Equal(t, ws.PrintGame(false), content)
+	assert.Equal(t, boardComponents(ws), components)
+
+	// the buttons are removed once the game is over
+	_ = ws.Guess(solution)
+	content, components = boardMessage(ws)
+	assert.Equal(t, ws.PrintGame(false), content)
+	assert.NotNil(t, components)
+	assert.Empty(t, components)
+}
+
+func TestBoardFlags(t *testing.T) {
+	ws := testSetup()
+	assert.Equal(t, discordgo.MessageFlags(1<<6), boardFlags(ws))
+	ws.Coop = true
+	assert.Equal(t, discordgo.MessageFlags(0), boardFlags(ws))
+}
@@ -1,40 +1,80 @@
 package game
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/saxypandabear/wordlego/config"
+	"github.com/saxypandabear/wordlego/puzzles"
+	"github.com/saxypandabear/wordlego/stats"
 	"github.com/saxypandabear/wordlego/words"
 )
 
-// keep track of the active sessions
-var sessions = make(map[string]*WordleSession) // TODO: how to make this survive an outage?
+// keep track of the active sessions. defaults to keeping them in memory,
+// see UseSessionStore to persist them.
+var sessions SessionStore = NewMemoryStore()
+
+// keep track of the results of completed games. defaults to keeping them in memory,
+// see UseStatsStore to persist them.
+var results stats.Store = stats.NewMemoryStore()
+
+// UseStatsStore replaces the store that keeps track of the results of completed games.
+// This should be called before the bot starts handling interactions.
+func UseStatsStore(store stats.Store) {
+	results = store
+}
+
+// UseSessionStore replaces the store that keeps track of the active sessions.
+// This should be called before the bot starts handling interactions.
+func UseSessionStore(store SessionStore) {
+	sessions = store
+}
+
+// names of the options for the wordle command
+const (
+	ActionOption     = "action"
+	WordOption       = "word"
+	PuzzleNumOption  = "puzzle-num"
+	MaxGuessesOption = "max-guesses"
+	KeyboardOption   = "keyboard"
+	HardModeOption   = "hard-mode"
+	WindowOption     = "window"
+	LengthOption     = "length"
+	LanguageOption   = "language"
+	ModeOption       = "mode"
+	TakeTurnsOption  = "take-turns"
+	OpponentOption   = "opponent"
+	CodeOption       = "code"
+	BoardsOption     = "boards"
+	HintTypeOption   = "hint-type"
+)
 
 type CommandArgs struct {
 	GameAction string
 	Word       string
 	PuzzleNum  int
 	MaxGuesses int
+	Keyboard   string
+	HardMode   bool
+	Window     stats.Window
+	Length     int
+	Language   string
+	Mode       string
+	TakeTurns  bool
+	Opponent   string
+	Code       string
+	Boards     int
+	HintKind   string
 }
 
 // Wordle is the hook for the bot to execute the wordle game functionality.
 // This acts as the main game loop.
 func Wordle(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	if i.Member == nil {
-		// this isn't being called from within a guild. TODO: allow playing Wordle in direct messages
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Flags:   1 << 6,
-				Content: "Wordle can only be played in a server.",
-			},
-		})
-	}
-
-	args := ParseCommandInputs(i.ApplicationCommandData())
+	args := parseCommandInputs(i.ApplicationCommandData(), configs.Get(i.GuildID))
 	switch args.GameAction {
 	case Start:
 		start(s, i, args)
@@ -42,190 +82,531 @@ func Wordle(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		stop(s, i, args)
 	case Guess:
 		guessWord(s, i, args)
+	case Hint:
+		hint(s, i, args)
 	case Help:
 		help(s, i, args)
+	case Stats:
+		showStats(s, i, args)
+	case Leaderboard:
+		showLeaderboard(s, i, args)
+	case Challenge:
+		challenge(s, i, args)
+	case Create:
+		create(s, i, args)
 	default:
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Flags:   1 << 6,
-				Content: "Invalid action",
-			},
-		})
+		respondEphemeral(s, i, "Invalid action")
 	}
 }
 
 // ParseCommandInputs takes the Discord application command data and then
 // returns a structured interface that has all of the arguments mapped to
-// a comprehensible name, for ease of use. This assumes that the action (at index 0)
-// exists, since that is a required argument.
+// a comprehensible name, for ease of use. Discord only sends the options that
+// the player filled in, so the options are looked up by name, and any options
+// that weren't provided fall back to their defaults.
 func ParseCommandInputs(data discordgo.ApplicationCommandInteractionData) *CommandArgs {
-	action := data.Options[0].Value.(string)
+	return parseCommandInputs(data, config.Default())
+}
+
+// parseCommandInputs works like ParseCommandInputs, but the options that weren't
+// provided fall back to the guild's configuration where it has a default for them.
+func parseCommandInputs(data discordgo.ApplicationCommandInteractionData, cfg config.GuildConfig) *CommandArgs {
+	args := CommandArgs{
+		PuzzleNum:  words.DetermineWordForDay(time.Now()),
+		MaxGuesses: cfg.MaxGuesses,
+		HardMode:   cfg.HardMode,
+		Window:     stats.Today,
+		Length:     words.DefaultLength,
+		Language:   cfg.Language,
+		TakeTurns:  cfg.CoopTakeTurns,
+		Boards:     1,
+		HintKind:   LetterHint,
+	}
+	if args.Language == "" {
+		args.Language = words.DefaultLanguage
+	}
+	for _, opt := range data.Options {
+		switch opt.Name {
+		case ActionOption:
+			args.GameAction = opt.StringValue()
+		case WordOption:
+			args.Word = strings.ToLower(opt.StringValue())
+		case PuzzleNumOption:
+			args.PuzzleNum = int(opt.IntValue())
+		case MaxGuessesOption:
+			args.MaxGuesses = int(opt.IntValue())
+		case KeyboardOption:
+			args.Keyboard = opt.StringValue()
+		case HardModeOption:
+			args.HardMode = opt.BoolValue()
+		case WindowOption:
+			args.Window = stats.Window(opt.StringValue())
+		case LengthOption:
+			args.Length = int(opt.IntValue())
+		case LanguageOption:
+			args.Language = opt.StringValue()
+		case ModeOption:
+			args.Mode = opt.StringValue()
+		case TakeTurnsOption:
+			args.TakeTurns = opt.BoolValue()
+		case OpponentOption:
+			args.Opponent = opt.UserValue(nil).ID
+		case CodeOption:
+			args.Code = puzzles.NormalizeCode(opt.StringValue())
+		case BoardsOption:
+			args.Boards = int(opt.IntValue())
+		case HintTypeOption:
+			args.HintKind = opt.StringValue()
+		}
+	}
+	if args.Keyboard == "" {
+		args.Keyboard = DefaultKeyboardLayout(args.Language).Name
+	}
+	// Discord already keeps the option in range, but the sessions can't be made with
+	// a negative number of guesses, and too many don't fit in the board's message
+	if args.MaxGuesses < 1 {
+		args.MaxGuesses = 1
+	} else if args.MaxGuesses > config.MaxMaxGuesses {
+		args.MaxGuesses = config.MaxMaxGuesses
+	}
+	return &args
+}
+
+// errors that are shown directly to the player
+var (
+	errActiveSession = errors.New("You already have an active game. Keep guessing, or use /wordle stop to cancel the active session.")
+	errActiveCoop    = errors.New("This channel already has an active coop game. Join in with /wordle guess, or use /wordle stop mode:coop to cancel it.")
+	errNoSession     = errors.New("You haven't started a game yet. Start one with /wordle start")
+	errNoGuess       = errors.New("No guess parameter provided")
+)
+
+// startSession creates a new game session under the ID for the player that started it,
+// and saves it in the session store. The ID is the player's for a solo game, or the
+// channel's coop session ID for a coop game, see coopSessionID. This returns
+// errActiveSession, or errActiveCoop for a coop game, if there is already a session
+// under the ID.
+// When the arguments have the code of a custom puzzle, the session is for that puzzle
+// instead of a numbered one. This returns errUnknownPuzzle if there's no such puzzle,
+// and errOwnPuzzle if the player is the one that created it, even for a coop game.
+// When the arguments ask for more than one board, the session is for a multi-board game
+// of the numbered puzzle, which gets extra guesses, see extraGuesses, and this returns
+// errMultiPuzzle if there aren't enough earlier puzzles for its words. In absurdle mode,
+// the session is for an adversarial game, which doesn't have a puzzle number.
+// The caller must hold the lock for the ID, see playerLocks.
+func startSession(id, player string, args *CommandArgs) (*WordleSession, error) {
+	coop := args.Mode == Coop
+	if _, exists := sessions.Get(id); exists {
+		if coop {
+			return nil, errActiveCoop
+		}
+		return nil, errActiveSession
+	}
+
+	var gameSession *WordleSession
+	switch {
+	case args.Mode == Absurdle:
+		var err error
+		if gameSession, err = newAdversarialSession(args.Language, args.Length, args.MaxGuesses); err != nil {
+			return nil, fmt.Errorf("failed to get the candidates for the game: %w", err)
+		}
+	case args.Boards > 1:
+		if _, ok := variants[args.Boards]; !ok {
+			return nil, fmt.Errorf("%d boards isn't a multi-board game", args.Boards)
+		}
+		sols, err := multiSolutions(args.Language, args.Length, args.PuzzleNum, args.Boards, words.DetermineWordForDay(time.Now()))
+		if errors.Is(err, errMultiPuzzle) {
+			return nil, err
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get the solutions for the game: %w", err)
+		}
+		gameSession = newMultiSession(sols, args.Language, args.MaxGuesses+extraGuesses[args.Boards], args.PuzzleNum)
+	case args.Code != "":
+		p, ok := customPuzzles.Get(args.Code)
+		if !ok {
+			return nil, errUnknownPuzzle
+		}
+		if p.Creator == player {
+			return nil, errOwnPuzzle
+		}
+		gameSession = NewSession(p.Word, args.MaxGuesses, 0)
+		gameSession.Language = p.Language
+	default:
+		sol, err := words.GetSolution(args.Language, args.Length, args.PuzzleNum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get a solution for the game: %w", err)
+		}
+		gameSession = NewSession(sol, args.MaxGuesses, args.PuzzleNum)
+		gameSession.Language = args.Language
+	}
+
+	gameSession.Custom = args.Code
+	gameSession.Keyboard = args.Keyboard
+	gameSession.HardMode = args.HardMode
+	gameSession.Coop = coop
+	gameSession.TakeTurns = coop && args.TakeTurns
+	gameSession.Practice = args.Mode == Practice
+	if err := sessions.Put(id, gameSession); err != nil {
+		return nil, fmt.Errorf("failed to save the game session: %w", err)
+	}
+	return gameSession, nil
+}
 
-	word := ""
-	if len(data.Options) >= 2 {
-		word = strings.ToLower(data.Options[1].StringValue())
+// guessSession validates the word and then guesses it for the player in the session
+// under the ID, which is either the player's own session or a coop session that
+// they're playing in. When the guess finishes the game, the session is removed from
+// the session store and the result is recorded, otherwise the updated session is saved.
+// The caller must hold the lock for the ID, see playerLocks.
+func guessSession(id, player, word string) (*WordleSession, error) {
+	sess, ok := sessions.Get(id)
+	if !ok {
+		return nil, errNoSession
+	}
+	if word == "" {
+		return nil, errNoGuess
+	}
+	if err := sess.CheckLength(word); err != nil {
+		return nil, err
+	}
+	if !words.IsGuessValidIn(sess.Language, word) {
+		return nil, fmt.Errorf("'%s' is not a valid guess", word)
+	}
+	if err := sess.GuessAs(player, word); err != nil {
+		return nil, err
 	}
 
-	var puzzleNum int
-	if len(data.Options) >= 3 {
-		puzzleNum = int(data.Options[2].IntValue())
+	var err error
+	if sess.CanPlay() {
+		err = sessions.Put(id, sess)
 	} else {
-		puzzleNum = words.DetermineWordForDay(time.Now())
+		err = sessions.Delete(id)
+		recordResult(id, sess)
 	}
+	if err != nil {
+		log.Printf("Exception occurred when trying to save the game session: %s\n", err.Error())
+	}
+	return sess, nil
+}
 
-	maxGuesses := DefaultMaxGuesses
-	if len(data.Options) >= 4 {
-		maxGuesses = int(data.Options[3].IntValue())
+// stopSession forfeits the active session under the ID, removes it from the
+// session store, and records the forfeit as a loss in the player's statistics.
+// This returns errNoSession if there isn't a session under the ID.
+// The caller must hold the lock for the ID, see playerLocks.
+func stopSession(id string) (*WordleSession, error) {
+	sess, ok := sessions.Get(id)
+	if !ok {
+		return nil, errNoSession
+	}
+	if err := sessions.Delete(id); err != nil {
+		return nil, err
 	}
+	sess.Forfeit()
+	recordResult(id, sess)
+	return sess, nil
+}
 
-	return &CommandArgs{
-		GameAction: action,
-		Word:       word,
-		PuzzleNum:  puzzleNum,
-		MaxGuesses: maxGuesses,
+// recordResult saves the result of the player's finished session in their statistics.
+// Coop sessions don't belong to any one player, so they aren't recorded.
+func recordResult(id string, sess *WordleSession) {
+	if sess.Coop {
+		return
+	}
+	if err := results.Record(sess.Result(id, time.Now())); err != nil {
+		log.Printf("Exception occurred when trying to record the result of a game: %s\n", err.Error())
 	}
 }
 
-// start initiates a new game for the user. if the user already has an
-// active game session, this emits a failure message to the user indicating such.
+// start initiates a new game for the user, or for the channel in coop mode. if
+// there is already an active game session, this emits a failure message to the
+// user indicating such. In practice mode, the puzzle is picked for the player,
+// see practicePuzzle, and in absurdle mode, there isn't a puzzle at all.
 func start(s *discordgo.Session, i *discordgo.InteractionCreate, args *CommandArgs) {
-	if _, exists := sessions[i.Member.User.ID]; exists {
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Flags:   1 << 6,
-				Content: "You already have an active game. Keep guessing, or use /wordle stop to cancel the active session.",
-			},
-		})
+	id := playerID(i.Interaction)
+	if args.Mode == Coop {
+		if i.GuildID == "" {
+			respondEphemeral(s, i, "Coop games can only be played in a server.")
+			return
+		}
+		id = coopSessionID(i.ChannelID)
+	}
+	defer players.lock(id)()
+
+	cfg, today := configs.Get(i.GuildID), words.DetermineWordForDay(time.Now())
+	switch {
+	case args.Boards > 1 && (args.Mode == Coop || args.Mode == Practice || args.Mode == Absurdle || args.Code != ""):
+		respondEphemeral(s, i, errMultiMode.Error())
+		return
+	case args.Mode == Practice && args.Code != "":
+		respondEphemeral(s, i, errPracticeCode.Error())
+		return
+	case args.Mode == Absurdle && args.Code != "":
+		respondEphemeral(s, i, errAbsurdleCode.Error())
+		return
+	case args.Mode == Practice:
+		puzzle, err := practicePuzzle(id, args.Language, args.Length, cfg, today)
+		if err != nil {
+			respondEphemeral(s, i, err.Error())
+			return
+		}
+		args.PuzzleNum = puzzle
+	case args.Code == "" && args.Mode != Absurdle && !cfg.AllowsPuzzle(args.PuzzleNum, today):
+		respondEphemeral(s, i, fmt.Sprintf("Wordle %d can't be played in this server.", args.PuzzleNum))
 		return
 	}
 
-	sol, err := words.GetSpecificWordleSolution(args.PuzzleNum)
+	gameSession, err := startSession(id, playerID(i.Interaction), args)
+	if errors.Is(err, errActiveSession) || errors.Is(err, errActiveCoop) ||
+		errors.Is(err, errUnknownPuzzle) || errors.Is(err, errOwnPuzzle) || errors.Is(err, errMultiPuzzle) {
+		respondEphemeral(s, i, err.Error())
+		return
+	}
 	if err != nil {
-		log.Printf("Exception occurred when trying to get a solution for the game: %s\n", err.Error())
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Flags:   1 << 6,
-				Content: "An error occurred when trying to get a solution for your game. Contact the bot owner.",
-			},
-		})
+		log.Printf("Exception occurred when trying to start a game: %s\n", err.Error())
+		respondEphemeral(s, i, "An error occurred when trying to start your game. Contact the bot owner.")
 		return
 	}
 
-	gameSession := NewSession(sol, args.MaxGuesses, args.PuzzleNum)
-	sessions[i.Member.User.ID] = gameSession
-
+	// the board is only visible to the player, since it shows the letters that were guessed,
+	// unless the whole channel is playing. it is edited in place for the rest of the game,
+	// see updateBoard
+	content, components := boardMessage(gameSession)
 	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: gameSession.PrintGame(false),
+			Flags:      boardFlags(gameSession),
+			Content:    content,
+			Components: components,
 		},
 	})
 
 	if err != nil {
-		delete(sessions, i.Member.User.ID) // if there was an error, undo the state change
+		sessions.Delete(id) // if there was an error, undo the state change
 		return
 	}
+
+	gameSession.Board = newBoard(i.Interaction, time.Now())
+	gameSession.Guild = i.GuildID
+	if err = sessions.Put(id, gameSession); err != nil {
+		log.Printf("Exception occurred when trying to save the game session: %s\n", err.Error())
+	}
 }
 
+// stop ends the user's active game, and shares the emoji grid for the game
+// as a forfeit. The solution is revealed behind a spoiler tag, since other
+// players in the channel could still be playing the same puzzle.
+// Any member of the channel can stop a coop game.
 func stop(s *discordgo.Session, i *discordgo.InteractionCreate, args *CommandArgs) {
+	id := sessionID(i.Interaction, args.Mode)
+	defer players.lock(id)()
+	defer lockMatch(id)()
+
+	sess, err := stopSession(id)
+	if errors.Is(err, errNoSession) {
+		respondEphemeral(s, i, "You don't have an active game to stop.")
+		return
+	}
+	if err != nil {
+		log.Printf("Exception occurred when trying to stop a game: %s\n", err.Error())
+		respondEphemeral(s, i, "An error occurred when trying to stop your game. Contact the bot owner.")
+		return
+	}
+
+	editBoard(s, sess) // take the buttons off of the board, if it's still around
+	if sess.Match != "" {
+		updateMatch(s, id, sess)
+	}
+	if sess.Custom != "" {
+		reportToCreator(s, i, sess)
+	}
+	content := fmt.Sprintf("You gave up! %s\n", sess.reveal())
+	if sess.Coop {
+		content = fmt.Sprintf("<@%s> gave up on the channel's game! %s\n", playerID(i.Interaction), sess.reveal())
+	}
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Flags:   1 << 6,
-			Content: "Not implemented yet",
+			Flags:           resultFlags(i),
+			Content:         content + sess.PrintGame(true),
+			Components:      resultComponents(sess, resultFlags(i)),
+			AllowedMentions: &discordgo.MessageAllowedMentions{}, // don't ping the players
 		},
 	})
 }
 
 func guessWord(s *discordgo.Session, i *discordgo.InteractionCreate, args *CommandArgs) {
-	var sess *WordleSession
-	var ok bool
-	if sess, ok = sessions[i.Member.User.ID]; !ok {
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Flags:   1 << 6,
-				Content: "You haven't started a game yet. Start one with /wordle start",
-			},
-		})
-		return
-	}
-	if args.Word == "" {
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Flags:   1 << 6,
-				Content: "No guess parameter provided",
-			},
-		})
+	player := playerID(i.Interaction)
+	id := sessionID(i.Interaction, args.Mode)
+	defer players.lock(id)()
+	defer lockMatch(id)()
+
+	sess, err := guessSession(id, player, args.Word)
+	if err != nil {
+		respondEphemeral(s, i, err.Error())
 		return
 	}
-	if !words.IsGuessValid(args.Word) {
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Flags:   1 << 6,
-				Content: fmt.Sprintf("'%s' is not a valid guess", args.Word),
-			},
-		})
-		return
+	if sess.Match != "" {
+		updateMatch(s, id, sess)
 	}
 
-	err := sess.Guess(args.Word)
-	if err != nil {
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Flags:   1 << 6,
-				Content: err.Error(),
-			},
-		})
+	responded := updateBoard(s, i, sess)
+	if sess.CanPlay() {
+		if !responded {
+			acknowledge(s, i)
+			return
+		}
+		// the board moved to this interaction, so save where it is now
+		if err = sessions.Put(id, sess); err != nil {
+			log.Printf("Exception occurred when trying to save the game session: %s\n", err.Error())
+		}
 		return
 	}
 
-	if sess.IsSolved() {
-		// player solved the puzzle. share it
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "You guessed the word!\n" + sess.PrintGame(true),
-			},
-		})
-		delete(sessions, i.Member.User.ID)
-		return
+	if sess.Custom != "" {
+		reportToCreator(s, i, sess)
 	}
-	if !sess.CanPlay() {
+	var content string
+	switch {
+	case sess.IsSolved() && sess.Coop:
+		content = fmt.Sprintf("<@%s> guessed the word for the channel!\n", player) + sess.PrintGame(true)
+	case sess.IsSolved():
+		// player solved the puzzle. share it
+		content = "You guessed the word!\n" + sess.PrintGame(true)
+	case sess.Coop:
+		content = "The channel ran out of guesses!\n" + sess.PrintGame(true)
+	default:
 		// can't play anymore because the player ran out of tries (different outcome
 		// than solving the puzzle).
-		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-			Type: discordgo.InteractionResponseChannelMessageWithSource,
-			Data: &discordgo.InteractionResponseData{
-				Content: "You ran out of guesses!\n" + sess.PrintGame(true),
-			},
+		content = "You ran out of guesses!\n" + sess.PrintGame(true)
+	}
+	if responded {
+		s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+			Flags:           resultFlags(i),
+			Content:         content,
+			Components:      resultComponents(sess, resultFlags(i)),
+			AllowedMentions: &discordgo.MessageAllowedMentions{}, // don't ping the players
 		})
-		delete(sessions, i.Member.User.ID)
 		return
 	}
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:           resultFlags(i),
+			Content:         content,
+			Components:      resultComponents(sess, resultFlags(i)),
+			AllowedMentions: &discordgo.MessageAllowedMentions{}, // don't ping the players
+		},
+	})
+}
 
+// resultFlags returns the message flags for sharing the result of a finished game,
+// which is only shown to the player if the guild keeps results private.
+func resultFlags(i *discordgo.InteractionCreate) discordgo.MessageFlags {
+	if configs.Get(i.GuildID).Spoilers == config.Private {
+		return 1 << 6
+	}
+	return 0
+}
+
+// showStats shares the player's statistics across all of their completed games.
+func showStats(s *discordgo.Session, i *discordgo.InteractionCreate, args *CommandArgs) {
+	id := playerID(i.Interaction)
+	st := stats.Compute(results.Results(id))
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Flags:   1 << 6,
-			Content: sess.PrintGame(false),
+			Content:         fmt.Sprintf("Statistics for <@%s>\n", id) + st.Format(),
+			AllowedMentions: &discordgo.MessageAllowedMentions{}, // don't ping the player
+		},
+	})
+}
+
+// showLeaderboard shares the guild's leaderboard for the daily puzzles.
+func showLeaderboard(s *discordgo.Session, i *discordgo.InteractionCreate, args *CommandArgs) {
+	if i.GuildID == "" {
+		respondEphemeral(s, i, "Leaderboards are only available in a server.")
+		return
+	}
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:         leaderboard(i.GuildID, words.DetermineWordForDay(time.Now()), args.Window),
+			AllowedMentions: &discordgo.MessageAllowedMentions{}, // don't ping everyone on the leaderboard
 		},
 	})
 }
 
+// leaderboard formats the guild's leaderboard for the window, given the puzzle of the day.
+func leaderboard(guild string, today int, window stats.Window) string {
+	var title string
+	switch window {
+	case stats.Today:
+		title = fmt.Sprintf("Wordle %d leaderboard", today)
+	case stats.Week:
+		title = "Wordle leaderboard for the last week"
+	case stats.Month:
+		title = "Wordle leaderboard for the last month"
+	default:
+		title = "Wordle leaderboard for all time"
+	}
+	standings := stats.Leaderboard(results.All(), guild, today, window)
+	return stats.FormatLeaderboard(title, standings, window)
+}
+
+// Recap summarizes the guild's results for the daily puzzle, with each player's
+// emoji grid. This is used to recap the daily puzzle once it's over.
+func Recap(guild string, puzzle int) string {
+	return stats.FormatRecap(results.All(), guild, puzzle)
+}
+
 // publish a help message to the user
 func help(s *discordgo.Session, i *discordgo.InteractionCreate, args *CommandArgs) {
+	respondEphemeral(s, i, "Not implemented yet")
+}
+
+// playerID returns the ID of the user that invoked the interaction. Discord only
+// fills in the Member when the interaction comes from a guild, and only fills in
+// the User when it comes from a direct message. Either way, this is the user's ID,
+// so a player's session is the same across all guilds and direct messages.
+func playerID(i *discordgo.Interaction) string {
+	if i.Member != nil {
+		return i.Member.User.ID
+	}
+	return i.User.ID
+}
+
+// coopSessionID returns the ID that the channel's coop session is stored under. Discord
+// IDs are numbers, so this can't be mistaken for a player's ID.
+func coopSessionID(channel string) string {
+	return "coop:" + channel
+}
+
+// sessionID returns the ID of the session that the interaction is for. The mode picks
+// either the player's own session, which is where practice games are too, or the
+// channel's coop session. When the mode isn't given, the player's own session is used,
+// unless they don't have one and the channel has a coop session.
+func sessionID(i *discordgo.Interaction, mode string) string {
+	player := playerID(i)
+	switch mode {
+	case Solo, Practice, Absurdle:
+		return player
+	case Coop:
+		return coopSessionID(i.ChannelID)
+	}
+	if _, ok := sessions.Get(player); !ok && i.GuildID != "" {
+		if _, ok = sessions.Get(coopSessionID(i.ChannelID)); ok {
+			return coopSessionID(i.ChannelID)
+		}
+	}
+	return player
+}
+
+// respondEphemeral responds to the interaction with a message that only the
+// player that invoked it can see.
+func respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
 			Flags:   1 << 6,
-			Content: "Not implemented yet",
+			Content: content,
 		},
 	})
 }
@@ -0,0 +1,443 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/saxypandabear/wordlego/config"
+	"github.com/saxypandabear/wordlego/stats"
+	"github.com/saxypandabear/wordlego/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommandInputs(t *testing.T) {
+	args := ParseCommandInputs(discordgo.ApplicationCommandInteractionData{
+		Name: "wordle",
+		Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			{Name: ActionOption, Type: discordgo.ApplicationCommandOptionString, Value: Start},
+		},
+	})
+	assert.Equal(t, &CommandArgs{
+		GameAction: Start,
+		PuzzleNum:  words.DetermineWordForDay(time.Now()),
+		MaxGuesses: DefaultMaxGuesses,
+		Keyboard:   QWERTY.Name,
+		Window:     stats.Today,
+		Length:     words.DefaultLength,
+		Language:   words.DefaultLanguage,
+		Boards:     1,
+		HintKind:   LetterHint,
+	}, args)
+
+	// options are only sent when they're filled in, so they can't be looked up by position
+	args = ParseCommandInputs(discordgo.ApplicationCommandInteractionData{
+		Name: "wordle",
+		Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			{Name: ActionOption, Type: discordgo.ApplicationCommandOptionString, Value: Start},
+			{Name: KeyboardOption, Type: discordgo.ApplicationCommandOptionString, Value: AZERTY.Name},
+			{Name: MaxGuessesOption, Type: discordgo.ApplicationCommandOptionInteger, Value: float64(8)},
+			{Name: PuzzleNumOption, Type: discordgo.ApplicationCommandOptionInteger, Value: float64(42)},
+			{Name: WordOption, Type: discordgo.ApplicationCommandOptionString, Value: "HeLLo"},
+			{Name: HardModeOption, Type: discordgo.ApplicationCommandOptionBoolean, Value: true},
+			{Name: WindowOption, Type: discordgo.ApplicationCommandOptionString, Value: "week"},
+			{Name: LengthOption, Type: discordgo.ApplicationCommandOptionInteger, Value: float64(6)},
+			{Name: LanguageOption, Type: discordgo.ApplicationCommandOptionString, Value: "es"},
+			{Name: ModeOption, Type: discordgo.ApplicationCommandOptionString, Value: Coop},
+			{Name: TakeTurnsOption, Type: discordgo.ApplicationCommandOptionBoolean, Value: true},
+			{Name: OpponentOption, Type: discordgo.ApplicationCommandOptionUser, Value: "opponent"},
+			{Name: CodeOption, Type: discordgo.ApplicationCommandOptionString, Value: " abc234 "},
+			{Name: BoardsOption, Type: discordgo.ApplicationCommandOptionInteger, Value: float64(4)},
+			{Name: HintTypeOption, Type: discordgo.ApplicationCommandOptionString, Value: CountHint},
+		},
+	})
+	assert.Equal(t, &CommandArgs{
+		GameAction: Start,
+		Word:       "hello",
+		PuzzleNum:  42,
+		MaxGuesses: 8,
+		Keyboard:   AZERTY.Name,
+		HardMode:   true,
+		Window:     stats.Week,
+		Length:     6,
+		Language:   "es",
+		Mode:       Coop,
+		TakeTurns:  true,
+		Opponent:   "opponent",
+		Code:       "ABC234",
+		Boards:     4,
+		HintKind:   CountHint,
+	}, args)
+}
+
+func TestParseCommandInputsGuildDefaults(t *testing.T) {
+	cfg := config.Default()
+	cfg.MaxGuesses = 8
+	cfg.HardMode = true
+	data := discordgo.ApplicationCommandInteractionData{
+		Name: "wordle",
+		Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			{Name: ActionOption, Type: discordgo.ApplicationCommandOptionString, Value: Start},
+		},
+	}
+	args := parseCommandInputs(data, cfg)
+	assert.Equal(t, 8, args.MaxGuesses)
+	assert.True(t, args.HardMode)
+
+	// the player's options take precedence over the guild's defaults
+	data.Options = append(data.Options,
+		&discordgo.ApplicationCommandInteractionDataOption{Name: MaxGuessesOption, Type: discordgo.ApplicationCommandOptionInteger, Value: float64(4)},
+		&discordgo.ApplicationCommandInteractionDataOption{Name: HardModeOption, Type: discordgo.ApplicationCommandOptionBoolean, Value: false},
+	)
+	args = parseCommandInputs(data, cfg)
+	assert.Equal(t, 4, args.MaxGuesses)
+	assert.False(t, args.HardMode)
+
+	// the number of guesses is kept in range
+	data.Options[1].Value = float64(-3)
+	assert.Equal(t, 1, parseCommandInputs(data, cfg).MaxGuesses)
+	data.Options[1].Value = float64(1000)
+	assert.Equal(t, config.MaxMaxGuesses, parseCommandInputs(data, cfg).MaxGuesses)
+
+	// the keyboard defaults to the layout for the language
+	cfg.Language = words.German.Code
+	args = parseCommandInputs(data, cfg)
+	assert.Equal(t, words.German.Code, args.Language)
+	assert.Equal(t, GermanQWERTZ.Name, args.Keyboard)
+}
+
+func TestPlayerID(t *testing.T) {
+	// from a guild
+	guild := &discordgo.Interaction{
+		GuildID: "guild",
+		Member:  &discordgo.Member{User: &discordgo.User{ID: "player"}},
+	}
+	assert.Equal(t, "player", playerID(guild))
+
+	// from a direct message
+	dm := &discordgo.Interaction{
+		User: &discordgo.User{ID: "player"},
+	}
+	assert.Equal(t, "player", playerID(dm))
+}
+
+func TestStartSession(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	args := &CommandArgs{GameAction: Start, PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage}
+
+	ws, err := startSession("player", "player", args)
+	assert.NoError(t, err)
+	assert.Equal(t, words.Solutions[0], ws.Solution)
+	stored, ok := sessions.Get("player")
+	assert.True(t, ok)
+	assert.Same(t, ws, stored)
+
+	_, err = startSession("player", "player", args)
+	assert.ErrorIs(t, err, errActiveSession)
+
+	args.Keyboard = QWERTZ.Name
+	ws, err = startSession("keyboard-player", "keyboard-player", args)
+	assert.NoError(t, err)
+	assert.Equal(t, QWERTZ.Name, ws.Keyboard)
+
+	args.PuzzleNum = len(words.Solutions) + 1
+	_, err = startSession("other-player", "other-player", args)
+	assert.Error(t, err)
+	_, ok = sessions.Get("other-player")
+	assert.False(t, ok)
+}
+
+func TestStartSessionLength(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	args := &CommandArgs{GameAction: Start, PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: 7, Language: words.DefaultLanguage}
+
+	ws, err := startSession("player", "player", args)
+	assert.NoError(t, err)
+	assert.Equal(t, words.SevenLetterSolutions[0], ws.Solution)
+
+	args.Length = 9
+	_, err = startSession("other-player", "other-player", args)
+	assert.EqualError(t, err, "failed to get a solution for the game: words must be between 4 and 8 letters long, not 9")
+}
+
+func TestGuessSessionLength(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	_, _ = startSession("player", "player", &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: 6, Language: words.DefaultLanguage})
+
+	// a valid word that is the wrong length
+	_, err := guessSession("player", "player", "hello")
+	assert.EqualError(t, err, "'hello' has 5 letters, but this puzzle's word has 6")
+	_, err = guessSession("player", "player", "zzzzzz")
+	assert.EqualError(t, err, "'zzzzzz' is not a valid guess")
+
+	ws, err := guessSession("player", "player", "banana")
+	assert.NoError(t, err)
+	assert.Len(t, ws.Attempts, 1)
+	ws, err = guessSession("player", "player", words.SixLetterSolutions[0])
+	assert.NoError(t, err)
+	assert.True(t, ws.IsSolved())
+}
+
+func TestSessionLanguage(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	args := &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.Spanish.Code}
+
+	ws, err := startSession("player", "player", args)
+	assert.NoError(t, err)
+	assert.Equal(t, words.SpanishSolutions[0], ws.Solution)
+	assert.Equal(t, words.Spanish.Code, ws.Language)
+
+	// guesses are checked against the words in the session's language
+	_, err = guessSession("player", "player", "hello")
+	assert.EqualError(t, err, "'hello' is not a valid guess")
+	ws, err = guessSession("player", "player", words.SpanishSolutions[0])
+	assert.NoError(t, err)
+	assert.True(t, ws.IsSolved())
+
+	args.Length = 6
+	_, err = startSession("other-player", "other-player", args)
+	assert.EqualError(t, err, "failed to get a solution for the game: there are no 6 letter words in Español")
+}
+
+func TestGuessSession(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	_, err := guessSession("player", "player", "hello")
+	assert.ErrorIs(t, err, errNoSession)
+
+	_, _ = startSession("player", "player", &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage})
+	_, err = guessSession("player", "player", "")
+	assert.ErrorIs(t, err, errNoGuess)
+	_, err = guessSession("player", "player", "lllll")
+	assert.EqualError(t, err, "'lllll' is not a valid guess")
+
+	ws, err := guessSession("player", "player", "hello")
+	assert.NoError(t, err)
+	assert.Len(t, ws.Attempts, 1)
+	_, ok := sessions.Get("player")
+	assert.True(t, ok)
+
+	// solving the puzzle ends the session
+	ws, err = guessSession("player", "player", words.Solutions[0])
+	assert.NoError(t, err)
+	assert.True(t, ws.IsSolved())
+	_, ok = sessions.Get("player")
+	assert.False(t, ok)
+}
+
+func TestStopSession(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	_, err := stopSession("player")
+	assert.ErrorIs(t, err, errNoSession)
+
+	_, _ = startSession("player", "player", &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage})
+	_, _ = guessSession("player", "player", "hello")
+	ws, err := stopSession("player")
+	assert.NoError(t, err)
+	assert.True(t, ws.IsForfeited())
+	assert.Equal(t, []string{"hello"}, ws.Attempts)
+	_, ok := sessions.Get("player")
+	assert.False(t, ok)
+
+	// the player can start over after giving up
+	_, err = startSession("player", "player", &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage})
+	assert.NoError(t, err)
+}
+
+func TestCoopSession(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	UseStatsStore(stats.NewMemoryStore())
+	id := coopSessionID("channel")
+	args := &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage, Mode: Coop, TakeTurns: true}
+
+	ws, err := startSession(id, "player", args)
+	assert.NoError(t, err)
+	assert.True(t, ws.Coop)
+	assert.True(t, ws.TakeTurns)
+	_, err = startSession(id, "player", args)
+	assert.ErrorIs(t, err, errActiveCoop)
+
+	// any member can guess, but not twice in a row
+	_, err = guessSession(id, "alice", "hello")
+	assert.NoError(t, err)
+	_, err = guessSession(id, "alice", "world")
+	assert.ErrorIs(t, err, errConsecutiveGuess)
+	_, err = guessSession(id, "bob", "world")
+	assert.NoError(t, err)
+	ws, err = guessSession(id, "alice", words.Solutions[0])
+	assert.NoError(t, err)
+	assert.True(t, ws.IsSolved())
+	assert.Equal(t, []string{"alice", "bob", "alice"}, ws.Guessers)
+
+	// coop games don't belong to any one player, so they aren't recorded
+	assert.Empty(t, results.All())
+	_, ok := sessions.Get(id)
+	assert.False(t, ok)
+}
+
+func TestSessionID(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	i := &discordgo.Interaction{
+		GuildID:   "guild",
+		ChannelID: "channel",
+		Member:    &discordgo.Member{User: &discordgo.User{ID: "player"}},
+	}
+	assert.Equal(t, "player", sessionID(i, ""))
+	assert.Equal(t, coopSessionID("channel"), sessionID(i, Coop))
+
+	// the channel's coop session is used when the player isn't playing on their own
+	_, _ = startSession(coopSessionID("channel"), "player", &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage, Mode: Coop})
+	assert.Equal(t, coopSessionID("channel"), sessionID(i, ""))
+	_, _ = startSession("player", "player", &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage})
+	assert.Equal(t, "player", sessionID(i, ""))
+	assert.Equal(t, coopSessionID("channel"), sessionID(i, Coop))
+	assert.Equal(t, "player", sessionID(i, Solo))
+	assert.Equal(t, "player", sessionID(i, Practice))
+}
+
+func TestFinishedGamesAreRecorded(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	UseStatsStore(stats.NewMemoryStore())
+	args := &CommandArgs{PuzzleNum: 1, MaxGuesses: 2, Length: words.DefaultLength, Language: words.DefaultLanguage}
+
+	// in progress games aren't recorded
+	_, _ = startSession("player", "player", args)
+	_, _ = guessSession("player", "player", "hello")
+	assert.Empty(t, results.Results("player"))
+	_, _ = guessSession("player", "player", words.Solutions[0])
+
+	_, _ = startSession("player", "player", args)
+	_, _ = guessSession("player", "player", "hello")
+	_, _ = guessSession("player", "player", "world")
+
+	_, _ = startSession("player", "player", args)
+	_, _ = stopSession("player")
+
+	recorded := results.Results("player")
+	assert.Len(t, recorded, 3)
+	assert.Equal(t, stats.Win, recorded[0].Outcome)
+	assert.Equal(t, 2, recorded[0].Guesses)
+	assert.Equal(t, stats.Loss, recorded[1].Outcome)
+	assert.Equal(t, stats.Forfeit, recorded[2].Outcome)
+	assert.Equal(t, 0, recorded[2].Guesses)
+	for _, r := range recorded {
+		assert.Equal(t, "player", r.Player)
+		assert.Equal(t, 1, r.Puzzle)
+		assert.Equal(t, 2, r.MaxGuesses)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	UseStatsStore(stats.NewMemoryStore())
+	assert.Equal(t, "Wordle 200 leaderboard\nNobody has played yet!", leaderboard("guild", 200, stats.Today))
+
+	started := time.Date(2022, time.January, 5, 12, 0, 0, 0, time.UTC)
+	results.Record(stats.Result{Player: "a", Guild: "guild", Puzzle: 200, Daily: true, Outcome: stats.Win, Guesses: 4, Started: started, Finished: started.Add(time.Minute)})
+	results.Record(stats.Result{Player: "b", Guild: "guild", Puzzle: 200, Daily: true, Outcome: stats.Win, Guesses: 3, Started: started, Finished: started.Add(time.Hour)})
+	results.Record(stats.Result{Player: "c", Guild: "other-guild", Puzzle: 200, Daily: true, Outcome: stats.Win, Guesses: 1})
+
+	assert.Equal(t, "Wordle 200 leaderboard\n1. <@b> 3 guesses in 1h0m0s\n2. <@a> 4 guesses in 1m0s\n", leaderboard("guild", 200, stats.Today))
+	assert.Equal(t, "Wordle leaderboard for the last week\n1. <@b> 1/1 wins, 3.00 average guesses\n2. <@a> 1/1 wins, 4.00 average guesses\n", leaderboard("guild", 201, stats.Week))
+	assert.True(t, strings.HasPrefix(leaderboard("guild", 201, stats.Month), "Wordle leaderboard for the last month\n"))
+	assert.True(t, strings.HasPrefix(leaderboard("guild", 201, stats.AllTime), "Wordle leaderboard for all time\n"))
+}
+
+func TestConcurrentGuessesForOneSession(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	_, _ = startSession("player", "player", &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage})
+	ws, _ := sessions.Get("player")
+
+	guesses := words.Solutions[1:21] // none of these are the solution for puzzle 1
+	results := make(chan error, len(guesses))
+	var wg sync.WaitGroup
+	for _, g := range guesses {
+		wg.Add(1)
+		go func(word string) {
+			defer wg.Done()
+			defer players.lock("player")()
+			_, err := guessSession("player", "player", word)
+			results <- err
+		}(g)
+	}
+	wg.Wait()
+	close(results)
+
+	succeeded := 0
+	for err := range results {
+		if err == nil {
+			succeeded++
+		} else {
+			assert.ErrorIs(t, err, errNoSession)
+		}
+	}
+	// only the allowed number of guesses should go through, and then the session ends
+	assert.Equal(t, allowedGuesses, succeeded)
+	assert.Len(t, ws.Attempts, allowedGuesses)
+	assert.Len(t, ws.Guesses, allowedGuesses)
+	_, ok := sessions.Get("player")
+	assert.False(t, ok)
+}
+
+func TestConcurrentRepeatedGuess(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	_, _ = startSession("player", "player", &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage})
+
+	results := make(chan error, 10)
+	var wg sync.WaitGroup
+	for n := 0; n < cap(results); n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer players.lock("player")()
+			_, err := guessSession("player", "player", "hello")
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	succeeded := 0
+	for err := range results {
+		if err == nil {
+			succeeded++
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+	ws, _ := sessions.Get("player")
+	assert.Equal(t, []string{"hello"}, ws.Attempts)
+}
+
+func TestConcurrentStartsAcrossPlayers(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	numPlayers := 50
+
+	var wg sync.WaitGroup
+	for n := 0; n < numPlayers; n++ {
+		// every player tries to start twice at the same time, and only one should win
+		for attempt := 0; attempt < 2; attempt++ {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				defer players.lock(id)()
+				ws, err := startSession(id, id, &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage})
+				if err != nil {
+					assert.ErrorIs(t, err, errActiveSession)
+					return
+				}
+				_, err = guessSession(id, id, "hello")
+				assert.NoError(t, err)
+				assert.Len(t, ws.Attempts, 1)
+			}(fmt.Sprintf("player-%d", n))
+		}
+	}
+	wg.Wait()
+
+	list := sessions.List()
+	assert.Len(t, list, numPlayers)
+	for _, ws := range list {
+		assert.Equal(t, []string{"hello"}, ws.Attempts)
+	}
+	assert.Empty(t, players.locks)
+}
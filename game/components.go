@@ -0,0 +1,161 @@
+package game
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// custom IDs for the message components and modals of the game
+const (
+	// Button on the board that opens the guess modal
+	GuessButtonID = "wordle-guess-button"
+	// Modal that the player types their guess into
+	GuessModalID = "wordle-guess-modal"
+	// Button on a coop board that opens the coop guess modal
+	CoopGuessButtonID = "wordle-coop-guess-button"
+	// Modal that a member types their guess for the channel's coop session into
+	CoopGuessModalID = "wordle-coop-guess-modal"
+	// Text input for the word within the guess modal
+	guessInputID = "word"
+)
+
+// boardComponents returns the buttons that are attached to the message that
+// displays an active game, so that the game can be played without retyping
+// the slash command for every guess. Coop boards have their own button, so that
+// a member that is also playing on their own guesses for the right session.
+func boardComponents(sess *WordleSession) []discordgo.MessageComponent {
+	buttonID := GuessButtonID
+	if sess.Coop {
+		buttonID = CoopGuessButtonID
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Guess",
+					Style:    discordgo.PrimaryButton,
+					CustomID: buttonID,
+				},
+			},
+		},
+	}
+}
+
+// resultComponents returns the buttons that are attached to the message with the
+// result of a finished session, which is posted with the flags: Again for practice
+// games, to play another one, and Analyze for games that can be analyzed without
+// spoiling them for anyone, see offerAnalysis.
+func resultComponents(sess *WordleSession, flags discordgo.MessageFlags) []discordgo.MessageComponent {
+	var buttons []discordgo.MessageComponent
+	if sess.Practice {
+		buttons = append(buttons, discordgo.Button{
+			Label:    "Again",
+			Style:    discordgo.PrimaryButton,
+			CustomID: practiceAgainID(sess),
+		})
+	}
+	if offerAnalysis(sess, flags&(1<<6) != 0, time.Now()) {
+		if id, err := analyzeID(sess); err == nil {
+			buttons = append(buttons, discordgo.Button{
+				Label:    "Analyze",
+				Style:    discordgo.SecondaryButton,
+				CustomID: id,
+			})
+		} else {
+			log.Printf("Exception occurred when trying to keep a game for analysis: %s\n", err.Error())
+		}
+	}
+	if len(buttons) == 0 {
+		return nil
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: buttons},
+	}
+}
+
+// GuessButton is the hook for the Guess button on the board. It responds to
+// the button click with a modal that the player enters their guess into.
+func GuessButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	showGuessModal(s, i, playerID(i.Interaction), GuessModalID)
+}
+
+// CoopGuessButton is the hook for the Guess button on a coop board, which works
+// like GuessButton for the channel's coop session.
+func CoopGuessButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	showGuessModal(s, i, coopSessionID(i.ChannelID), CoopGuessModalID)
+}
+
+// showGuessModal responds to the interaction with the modal for guessing in the
+// session under the ID.
+func showGuessModal(s *discordgo.Session, i *discordgo.InteractionCreate, id, modalID string) {
+	defer players.lock(id)()
+
+	sess, ok := sessions.Get(id)
+	if !ok {
+		respondEphemeral(s, i, errNoSession.Error())
+		return
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: modalID,
+			Title:    sess.title(),
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:  guessInputID,
+							Label:     "Guess",
+							Style:     discordgo.TextInputShort,
+							Required:  true,
+							MinLength: sess.length(),
+							MaxLength: sess.length(),
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// GuessModal is the hook for submitting the guess modal. The submitted word
+// is played exactly like a guess from the slash command.
+func GuessModal(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	args := &CommandArgs{
+		GameAction: Guess,
+		Word:       parseModalWord(i.ModalSubmitData()),
+		Mode:       Solo,
+	}
+	guessWord(s, i, args)
+}
+
+// CoopGuessModal is the hook for submitting the coop guess modal, which guesses
+// for the channel's coop session.
+func CoopGuessModal(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	args := &CommandArgs{
+		GameAction: Guess,
+		Word:       parseModalWord(i.ModalSubmitData()),
+		Mode:       Coop,
+	}
+	guessWord(s, i, args)
+}
+
+// parseModalWord finds the word that the player entered in the guess modal.
+func parseModalWord(data discordgo.ModalSubmitInteractionData) string {
+	for _, c := range data.Components {
+		row, ok := c.(*discordgo.ActionsRow)
+		if !ok {
+			continue
+		}
+		for _, rc := range row.Components {
+			if input, ok := rc.(*discordgo.TextInput); ok && input.CustomID == guessInputID {
+				return strings.ToLower(strings.TrimSpace(input.Value))
+			}
+		}
+	}
+	return ""
+}
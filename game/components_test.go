@@ -0,0 +1,51 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseModalWord(t *testing.T) {
+	// modal submissions are unmarshalled from Discord's payload
+	payload := `{
+		"custom_id": "wordle-guess-modal",
+		"components": [{
+			"type": 1,
+			"components": [{"type": 4, "custom_id": "word", "value": " HeLLo "}]
+		}]
+	}`
+	var data discordgo.ModalSubmitInteractionData
+	assert.NoError(t, json.Unmarshal([]byte(payload), &data))
+	assert.Equal(t, GuessModalID, data.CustomID)
+	assert.Equal(t, "hello", parseModalWord(data))
+}
+
+func TestParseModalWordMissingInput(t *testing.T) {
+	data := discordgo.ModalSubmitInteractionData{
+		CustomID: GuessModalID,
+		Components: []discordgo.MessageComponent{
+			&discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					&discordgo.TextInput{CustomID: "something-else", Value: "hello"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, "", parseModalWord(data))
+}
+
+func TestBoardComponents(t *testing.T) {
+	ws := testSetup()
+	components := boardComponents(ws)
+	assert.Len(t, components, 1)
+	row := components[0].(discordgo.ActionsRow)
+	assert.Len(t, row.Components, 1)
+	assert.Equal(t, GuessButtonID, row.Components[0].(discordgo.Button).CustomID)
+
+	ws.Coop = true
+	row = boardComponents(ws)[0].(discordgo.ActionsRow)
+	assert.Equal(t, CoopGuessButtonID, row.Components[0].(discordgo.Button).CustomID)
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/saxypandabear/wordlego/puzzles"
+	"github.com/saxypandabear/wordlego/words"
+)
+
+// keep track of the custom puzzles that players have created. defaults to keeping
+// them in memory, see UsePuzzleStore to persist them.
+var customPuzzles puzzles.Store = puzzles.NewMemoryStore()
+
+// UsePuzzleStore replaces the store that keeps track of the custom puzzles.
+// This should be called before the bot starts handling interactions.
+func UsePuzzleStore(store puzzles.Store) {
+	customPuzzles = store
+}
+
+// errors that are shown directly to the player
+var (
+	errUnknownPuzzle = errors.New("There isn't a puzzle with that code. Check that it was typed in correctly.")
+	errOwnPuzzle     = errors.New("You created this puzzle, so you already know the word! Share the code with someone else.")
+)
+
+// the number of times to generate a new code when the code is already taken, which
+// is very unlikely to happen even once
+const codeAttempts = 5
+
+// createPuzzle validates the word, and saves a custom puzzle for it with a new code.
+// The errors describe what's wrong with the word, so they can be shown to the player.
+func createPuzzle(creator, guild, language, word string) (puzzles.Puzzle, error) {
+	if word == "" {
+		return puzzles.Puzzle{}, errors.New("No word parameter provided")
+	}
+	if n := utf8.RuneCountInString(word); !words.IsValidLength(n) {
+		return puzzles.Puzzle{}, fmt.Errorf("Puzzles must be between %d and %d letters long, but '%s' has %d.", words.MinLength, words.MaxLength, word, n)
+	}
+	if !words.IsGuessValidIn(language, word) {
+		return puzzles.Puzzle{}, fmt.Errorf("'%s' is not a valid word", word)
+	}
+
+	p := puzzles.Puzzle{
+		Word:     word,
+		Language: language,
+		Creator:  creator,
+		Guild:    guild,
+		Created:  time.Now().UTC().Round(0),
+	}
+	var err error
+	for attempt := 0; attempt < codeAttempts; attempt++ {
+		if p.Code, err = puzzles.NewCode(); err != nil {
+			return puzzles.Puzzle{}, err
+		}
+		if err = customPuzzles.Add(p); !errors.Is(err, puzzles.ErrCodeTaken) {
+			break
+		}
+	}
+	return p, err
+}
+
+// create saves a custom puzzle with the player's word, and gives them the code to
+// share it with. The response is only shown to the player, so the word isn't leaked.
+func create(s *discordgo.Session, i *discordgo.InteractionCreate, args *CommandArgs) {
+	p, err := createPuzzle(playerID(i.Interaction), i.GuildID, args.Language, args.Word)
+	if err != nil {
+		if errors.Is(err, puzzles.ErrCodeTaken) {
+			log.Printf("Exception occurred when trying to create a puzzle: %s\n", err.Error())
+			err = errors.New("An error occurred when trying to create your puzzle. Contact the bot owner.")
+		}
+		respondEphemeral(s, i, err.Error())
+		return
+	}
+	respondEphemeral(s, i, fmt.Sprintf("Your puzzle code is `%s`. Anyone you share it with can play it with "+
+		"`/wordle action:start code:%s`, and you'll get a message with each of their results.", p.Code, p.Code))
+}
+
+// reportToCreator sends the creator of the custom puzzle a direct message with the
+// result of the session that was just finished, as emojis only.
+func reportToCreator(s *discordgo.Session, i *discordgo.InteractionCreate, sess *WordleSession) {
+	p, ok := customPuzzles.Get(sess.Custom)
+	if !ok {
+		return
+	}
+	who := fmt.Sprintf("<@%s>", playerID(i.Interaction))
+	if sess.Coop {
+		who = fmt.Sprintf("Everyone in <#%s>", i.ChannelID)
+	}
+	ch, err := s.UserChannelCreate(p.Creator)
+	if err == nil {
+		_, err = s.ChannelMessageSendComplex(ch.ID, &discordgo.MessageSend{
+			Content:         fmt.Sprintf("%s played your puzzle `%s`!\n", who, p.Code) + sess.PrintGame(true),
+			AllowedMentions: &discordgo.MessageAllowedMentions{}, // don't ping the player
+		})
+	}
+	if err != nil {
+		log.Printf("Exception occurred when trying to report a result to the creator of a puzzle: %s\n", err.Error())
+	}
+}
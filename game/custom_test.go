@@ -0,0 +1,85 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saxypandabear/wordlego/puzzles"
+	"github.com/saxypandabear/wordlego/stats"
+	"github.com/saxypandabear/wordlego/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePuzzle(t *testing.T) {
+	UsePuzzleStore(puzzles.NewMemoryStore())
+
+	p, err := createPuzzle("creator", "guild", words.DefaultLanguage, "party")
+	assert.NoError(t, err)
+	assert.Len(t, p.Code, 6)
+	assert.Equal(t, "party", p.Word)
+	assert.Equal(t, "creator", p.Creator)
+	assert.Equal(t, "guild", p.Guild)
+	stored, ok := customPuzzles.Get(p.Code)
+	assert.True(t, ok)
+	assert.Equal(t, p, stored)
+
+	// other lengths and languages work too
+	p, err = createPuzzle("creator", "guild", words.DefaultLanguage, "garden")
+	assert.NoError(t, err)
+	assert.Equal(t, "garden", p.Word)
+	p, err = createPuzzle("creator", "guild", words.German.Code, "glück")
+	assert.NoError(t, err)
+	assert.Equal(t, words.German.Code, p.Language)
+
+	_, err = createPuzzle("creator", "guild", words.DefaultLanguage, "")
+	assert.EqualError(t, err, "No word parameter provided")
+	_, err = createPuzzle("creator", "guild", words.DefaultLanguage, "abc")
+	assert.EqualError(t, err, "Puzzles must be between 4 and 8 letters long, but 'abc' has 3.")
+	_, err = createPuzzle("creator", "guild", words.DefaultLanguage, "zzzzz")
+	assert.EqualError(t, err, "'zzzzz' is not a valid word")
+	_, err = createPuzzle("creator", "guild", words.Spanish.Code, "party")
+	assert.EqualError(t, err, "'party' is not a valid word")
+}
+
+func TestStartCustomPuzzle(t *testing.T) {
+	UsePuzzleStore(puzzles.NewMemoryStore())
+	UseSessionStore(NewMemoryStore())
+	UseStatsStore(stats.NewMemoryStore())
+	p, err := createPuzzle("creator", "guild", words.German.Code, "glück")
+	assert.NoError(t, err)
+
+	args := &CommandArgs{PuzzleNum: words.DetermineWordForDay(time.Now()), MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage, Code: p.Code}
+	ws, err := startSession("player", "player", args)
+	assert.NoError(t, err)
+	assert.Equal(t, "glück", ws.Solution)
+	assert.Equal(t, words.German.Code, ws.Language)
+	assert.Equal(t, p.Code, ws.Custom)
+	assert.Equal(t, 0, ws.Puzzle)
+
+	ws, err = guessSession("player", "player", "glück")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(ws.PrintGame(true), "```ansi\nWordle "+p.Code+" (Deutsch): 1/6\n"))
+	recorded := results.Results("player")
+	assert.Len(t, recorded, 1)
+	assert.False(t, recorded[0].Daily)
+	assert.Equal(t, p.Code, recorded[0].Custom)
+	assert.Zero(t, stats.Compute(recorded).Played)
+
+	// the creator already knows the word
+	_, err = startSession("creator", "creator", args)
+	assert.ErrorIs(t, err, errOwnPuzzle)
+	// nor can they give it away to the channel in a coop game
+	args.Mode = Coop
+	_, err = startSession(coopSessionID("channel"), "creator", args)
+	assert.ErrorIs(t, err, errOwnPuzzle)
+	_, ok := sessions.Get(coopSessionID("channel"))
+	assert.False(t, ok)
+	args.Mode = ""
+
+	args.Code = "NOPE22"
+	_, err = startSession("other-player", "other-player", args)
+	assert.ErrorIs(t, err, errUnknownPuzzle)
+	_, ok = sessions.Get("other-player")
+	assert.False(t, ok)
+}
@@ -1,20 +1,32 @@
 package game
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/saxypandabear/wordlego/config"
 	"github.com/saxypandabear/wordlego/guess"
+	"github.com/saxypandabear/wordlego/stats"
+	"github.com/saxypandabear/wordlego/words"
 )
 
-const DefaultMaxGuesses = 6
+const DefaultMaxGuesses = config.DefaultMaxGuesses
 
 const (
 	// Initiate a new game of Wordle.
 	// Acceptable optional inputs:
 	// 1. puzzle-num = Solution number for a specific word to guess - defaults to current day
-	// 1. max-guesses = configurable maximum number of guesses for the puzzle - defaults to 6
+	// 1. max-guesses = configurable maximum number of guesses for the puzzle - defaults to the guild's configuration, or 6
+	// 1. keyboard = keyboard layout for displaying the used letters - defaults to the language's layout
+	// 1. hard-mode = whether revealed hints must be used in subsequent guesses - defaults to the guild's configuration, or false
+	// 1. mode = solo, coop to share the game with the channel, practice for a random puzzle that the player hasn't played, or absurdle for an adversarial game - defaults to solo
+	// 1. take-turns = whether coop members have to take turns guessing - defaults to the guild's configuration, or false
+	// 1. code = code of a custom puzzle to play instead of a numbered puzzle, see Create
+	// 1. boards = number of boards to solve at once with the same guesses, see extraGuesses - defaults to 1
 	Start string = "start"
 	// Terminates an active game of Wordle for the player
 	Stop string = "stop"
@@ -22,34 +34,92 @@ const (
 	// Acceptable required inputs:
 	// 1. word = the attempted guess for the puzzle
 	Guess string = "guess"
+	// Reveals something about the solution of the current active game session, in exchange for a guess.
+	// Acceptable optional inputs:
+	// 1. hint-type = letter to reveal a letter, or count to reveal how many words are left, see TakeHint - defaults to letter
+	Hint string = "hint"
 	// Prints out information on the different actions and parameters to the user
 	Help string = "help"
+	// Shows the player's statistics across all of their completed games
+	Stats string = "stats"
+	// Ranks the players in the guild by their results for the daily puzzles.
+	// Acceptable optional inputs:
+	// 1. window = range of daily puzzles to rank by - defaults to today
+	Leaderboard string = "leaderboard"
+	// Races another member of the guild to solve the same, randomly chosen, puzzle.
+	// Acceptable required inputs:
+	// 1. opponent = the member to race against
+	Challenge string = "challenge"
+	// Creates a custom puzzle with the player's word, for others to play with its code.
+	// Acceptable required inputs:
+	// 1. word = the solution for the puzzle
+	Create string = "create"
 )
 
+// the modes that a game can be played in
+const (
+	// A game for a single player. This is the default.
+	Solo string = "solo"
+	// A game that is shared by a channel, where any member can guess
+	Coop string = "coop"
+	// A game for a single player, with a random puzzle that they haven't played before.
+	// Practice games don't count towards the player's statistics or the leaderboards
+	Practice string = "practice"
+	// A game for a single player, where the solution keeps changing to dodge their guesses.
+	// Adversarial games don't count towards the daily leaderboards, see dodge
+	Absurdle string = "absurdle"
+)
+
+// errConsecutiveGuess is returned when a member of a coop session that takes turns
+// tries to guess twice in a row.
+var errConsecutiveGuess = errors.New("You made the last guess, so someone else has to guess next.")
+
 // The struct keeps track of an individual user's guesses.
 // It also keeps track of the letters individually so it doesn't have
 // to be computed over every guess, every time.
-// The Letters array uses ternary state. See FormatGuess for the explanation of
-// this state. It is duplicated in the Guess struct for simplicity
+// The Letters map uses the guess.Correctness states, where a letter that hasn't
+// been guessed yet is guess.Unknown, so it is missing from the map. See FormatGuess
+// for the explanation of the rest of the states. It is duplicated in the Guess struct
+// for simplicity, which is also why it isn't persisted, see UnmarshalJSON.
 type WordleSession struct {
-	Puzzle            int            // the number of the specific Wordle puzzle
-	Solution          string         // the solution for the given session that the player must guess
-	Letters           []int          // an array of ints that should be of size 26 to represent the chars
-	Guesses           []*guess.Guess // guesses from the user, tracking correctness
-	Attempts          []string       // raw guesses from the user
-	MaxAllowedGuesses int            // the maximum number of attempts the player has to guess the solution
-	solved            bool           // flag that is used to determine that the solution has been guessed correctly
+	Puzzle            int                        // the number of the specific Wordle puzzle
+	Solution          string                     // the solution for the given session that the player must guess
+	Language          string                     // the code of the language that the words are in, see words.Languages
+	Letters           map[rune]guess.Correctness `json:"-"` // the best state of each letter that has been guessed
+	Guesses           []*guess.Guess             // guesses from the user, tracking correctness
+	Attempts          []string                   // raw guesses from the user
+	MaxAllowedGuesses int                        // the maximum number of attempts the player has to guess the solution
+	Keyboard          string                     // the name of the keyboard layout used to display the used letters
+	HardMode          bool                       // whether revealed hints must be used in subsequent guesses
+	Board             *Board                     // the Discord message that displays the session, if any
+	Guild             string                     // ID of the guild the session was started in. empty for direct messages
+	Started           time.Time                  // when the session was created
+	Coop              bool                       // whether the session is shared by a channel, see GuessAs
+	TakeTurns         bool                       // whether members of a coop session have to take turns guessing
+	Guessers          []string                   // IDs of the members that made each guess in a coop session
+	Match             string                     // ID of the race that the session is part of, if any, see Match
+	Custom            string                     // code of the custom puzzle that the session is for, if any
+	Practice          bool                       // whether the session is a practice game, which isn't counted in the statistics
+	Boards            []*WordleSession           // the boards of a multi-board game, which each guess is applied to, see guessBoards
+	Adversarial       bool                       // whether the solution changes to dodge the guesses, see dodge
+	Candidates        []string                   `json:"-"` // the words that the solution of an adversarial session can still be
+	Hints             []TakenHint                // the hints that were taken, which each cost a guess, see TakeHint
+	solved            bool                       // flag that is used to determine that the solution has been guessed correctly
+	forfeited         bool                       // flag that is used to determine that the player gave up on the puzzle
 }
 
-// NewSession creates a new session given a solution, Discord message ID and max number
-// of allowed guesses.
+// NewSession creates a new session given a solution, max number of allowed guesses
+// and the puzzle number. The Discord message that displays the session is attached
+// separately once it is posted, see Board.
 func NewSession(solution string, allowedGuesses, puzzleNum int) *WordleSession {
 	ws := WordleSession{
 		Puzzle:            puzzleNum,
 		Solution:          solution,
-		Letters:           make([]int, 26),
+		Language:          words.DefaultLanguage,
+		Letters:           make(map[rune]guess.Correctness),
 		Guesses:           make([]*guess.Guess, 0, allowedGuesses),
 		MaxAllowedGuesses: allowedGuesses,
+		Started:           time.Now().UTC().Round(0), // drop the monotonic clock, so it matches once it's persisted
 	}
 
 	return &ws
@@ -67,26 +137,108 @@ func (ws *WordleSession) PrintGame(hideGuesses bool) string {
 	var b strings.Builder
 	// TODO: implement
 	b.WriteString("```ansi\n") // start ANSI code block
-	b.WriteString(fmt.Sprintf("Wordle %d: %d/%d\n", ws.Puzzle, len(ws.Attempts), ws.MaxAllowedGuesses))
+	hardMode := ""             // hard mode results are marked with an asterisk
+	if ws.HardMode {
+		hardMode = "*"
+	}
+	score := fmt.Sprint(len(ws.Attempts))
+	var notes []string
+	if ws.IsForfeited() {
+		score = "X"
+		notes = append(notes, "forfeit")
+	}
+	if len(ws.Hints) > 0 {
+		notes = append(notes, "hinted")
+	}
+	b.WriteString(fmt.Sprintf("%s: %s/%d%s", ws.title(), score, ws.MaxAllowedGuesses, hardMode))
+	if len(notes) > 0 {
+		b.WriteString(fmt.Sprintf(" (%s)", strings.Join(notes, ", ")))
+	}
+	b.WriteString("\n")
 	b.WriteString(displayedGuesses)
 
 	if !hideGuesses {
+		b.WriteString(ws.FormatHints())
 		b.WriteString("\n")
 		b.WriteString(ws.FormatUsedLetters())
 	}
 	b.WriteString("```") // close code block
+	if ws.Coop && len(ws.Guessers) > 0 {
+		b.WriteString("\n")
+		b.WriteString(ws.FormatGuessers())
+	}
 	return b.String()
 }
 
+// FormatGuessers attributes each of the guesses in a coop session to the member that
+// made it, numbered the same way as the rows of the board. Members are mentioned, so
+// the message shouldn't be allowed to ping them.
+func (ws *WordleSession) FormatGuessers() string {
+	rows := make([]string, len(ws.Guessers))
+	for i, player := range ws.Guessers {
+		rows[i] = fmt.Sprintf("%d. <@%s>", i+1, player)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// title names the puzzle, calling out the length of the word when it isn't the
+// usual five letters, and the language when it isn't English. The puzzle number of
+// a race isn't shown, since it could be used to look up the solution, and custom
+// puzzles go by their code instead. Practice games are called out as such, and
+// multi-board games go by the name of their variant.
+func (ws *WordleSession) title() string {
+	name := fmt.Sprintf("Wordle %d", ws.Puzzle)
+	switch {
+	case len(ws.Boards) > 0:
+		name = fmt.Sprintf("%s %d", variants[len(ws.Boards)], ws.Puzzle)
+	case ws.Adversarial:
+		name = "Absurdle"
+	case ws.Match != "":
+		name = "Wordle race"
+	case ws.Custom != "":
+		name = fmt.Sprintf("Wordle %s", ws.Custom)
+	}
+	var details []string
+	if ws.Practice {
+		details = append(details, "practice")
+	}
+	if length := ws.length(); length != words.DefaultLength {
+		details = append(details, fmt.Sprintf("%d letters", length))
+	}
+	if ws.Language != words.DefaultLanguage {
+		lang, _ := words.GetLanguage(ws.Language)
+		details = append(details, lang.Name)
+	}
+	if len(details) > 0 {
+		return fmt.Sprintf("%s (%s)", name, strings.Join(details, ", "))
+	}
+	return name
+}
+
+// isDaily returns whether the session is for the daily puzzle, which is the usual
+// five letter English puzzle for the day that the session was started. Races, custom
+// puzzles, practice games, multi-board games and adversarial games are never the daily
+// puzzle, even if they happen to have the same word.
+func (ws *WordleSession) isDaily() bool {
+	return ws.Match == "" && ws.Custom == "" && !ws.Practice && len(ws.Boards) == 0 && !ws.Adversarial &&
+		ws.Puzzle == words.DetermineWordForDay(ws.Started) &&
+		utf8.RuneCountInString(ws.Solution) == words.DefaultLength &&
+		ws.Language == words.DefaultLanguage
+}
+
 // FormatGuesses takes all of the current guesses in the session, and generates
 // the ANSI formatted string to display in Discord that highlights the letters
 // in the guesses based on Wordle rules. See wordlego/guess for the formatting
 // rules. This function formats all of the guesses with the characters visible.
+// The boards of a multi-board game are laid out side by side, see formatBoards.
 func (ws *WordleSession) FormatGuesses(enclosed bool) string {
 	var b strings.Builder
 	if enclosed {
 		b.WriteString("```ansi\n") // start ANSI code block
 	}
+	if len(ws.Boards) > 0 {
+		b.WriteString(ws.formatBoards())
+	}
 	for _, g := range ws.Guesses {
 		b.WriteString(guess.FormatGuess(g) + "\n")
 	}
@@ -98,12 +250,16 @@ func (ws *WordleSession) FormatGuesses(enclosed bool) string {
 
 // FormatEmojis takes all of the current guesses in the session, and generates
 // the ANSI formatted string to display in Discord that shows all of the guesses
-// in emoji form, in the popularized Wordle format.
+// in emoji form, in the popularized Wordle format. A multi-board game is summarized
+// by its scores instead, see formatScores.
 func (ws *WordleSession) FormatEmojis(enclosed bool) string {
 	var b strings.Builder
 	if enclosed {
 		b.WriteString("```ansi\n") // start ANSI code block
 	}
+	if len(ws.Boards) > 0 {
+		b.WriteString(ws.formatScores())
+	}
 	for _, g := range ws.Guesses {
 		b.WriteString(guess.FormatGuessToEmojis(g) + "\n")
 	}
@@ -114,30 +270,115 @@ func (ws *WordleSession) FormatEmojis(enclosed bool) string {
 }
 
 // FormatUsedLetters takes all of the Letters and formats a string that illustrates
-// the letters that have been used and their correctness.
-// TODO:
-// Try to lay it out in an American QWERTY keyboard -
-// qwertyuiop
-// asdfghjkl
-// zxcvbnm
-// This is not a requirement to start, but would be good to implement, so leaving the
-// todo here.
+// the letters that have been used and their correctness. The letters are laid
+// out like the keyboard that the player picked, see KeyboardLayout.
 func (ws *WordleSession) FormatUsedLetters() string {
-	// TODO: implement this
-	return "foo"
+	lang, _ := words.GetLanguage(ws.Language)
+	return GetKeyboardLayout(ws.Keyboard).WithAlphabet(lang.Alphabet).Format(ws.Letters)
 }
 
 // CanPlay verifies that the number of guesses in the session does not exceed
 // the allowed number of guesses for the given session, and the puzzle hasn't
-// already been completed
+// already been completed or given up on
 func (ws *WordleSession) CanPlay() bool {
-	return !ws.IsSolved() && len(ws.Attempts) < ws.MaxAllowedGuesses
+	return !ws.IsSolved() && !ws.IsForfeited() && len(ws.Attempts) < ws.MaxAllowedGuesses
 }
 
 func (ws *WordleSession) IsSolved() bool {
 	return ws.solved
 }
 
+// Forfeit ends the session without solving the puzzle. A forfeited session
+// counts as a loss, no matter how many guesses the player had left.
+func (ws *WordleSession) Forfeit() {
+	ws.forfeited = true
+}
+
+func (ws *WordleSession) IsForfeited() bool {
+	return ws.forfeited
+}
+
+// Result returns the record of the session for the player's statistics. This
+// should only be called once the session is over, see CanPlay.
+// The result only counts as the daily puzzle if the session was for the puzzle of
+// the day that it was started on, so replaying older puzzles doesn't affect the
+// daily leaderboards, and practice games and custom puzzles are marked so that they're
+// left out of the player's statistics. Each hint counts as one of the guesses, since it
+// took one of them away, so a hinted game never scores better than the same game without.
+func (ws *WordleSession) Result(player string, finished time.Time) stats.Result {
+	outcome := stats.Loss
+	if ws.IsSolved() {
+		outcome = stats.Win
+	} else if ws.IsForfeited() {
+		outcome = stats.Forfeit
+	}
+	return stats.Result{
+		Player:     player,
+		Puzzle:     ws.Puzzle,
+		Guesses:    len(ws.Attempts) + len(ws.Hints),
+		MaxGuesses: ws.MaxAllowedGuesses + len(ws.Hints),
+		HardMode:   ws.HardMode,
+		Outcome:    outcome,
+		Grid:       strings.TrimSuffix(ws.FormatEmojis(false), "\n"),
+		Guild:      ws.Guild,
+		Daily:      ws.isDaily(),
+		Practice:   ws.Practice,
+		Custom:     ws.Custom,
+		Hinted:     len(ws.Hints) > 0,
+		Language:   ws.Language,
+		Solution:   strings.Join(ws.solutions(), ","),
+		Started:    ws.Started,
+		Finished:   finished,
+	}
+}
+
+// sessionAlias has all of the same fields as a WordleSession, without its
+// methods, so that it can be marshalled with the default encoding rules.
+type sessionAlias WordleSession
+
+// sessionJSON is the serialized form of a WordleSession. The solved flag
+// is unexported, so it has to be carried over explicitly.
+type sessionJSON struct {
+	*sessionAlias
+	Solved bool
+}
+
+// MarshalJSON serializes the session, including whether or not it has been solved,
+// so that a session can be persisted and restored.
+func (ws *WordleSession) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sessionJSON{
+		sessionAlias: (*sessionAlias)(ws),
+		Solved:       ws.solved,
+	})
+}
+
+// UnmarshalJSON restores a session that was serialized with MarshalJSON. The used
+// letters are rebuilt from the guesses, as are the candidates of an adversarial session,
+// and sessions that were saved before games could be played in other languages are in English.
+func (ws *WordleSession) UnmarshalJSON(data []byte) error {
+	aux := sessionJSON{sessionAlias: (*sessionAlias)(ws)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	ws.solved = aux.Solved
+	if ws.Language == "" {
+		ws.Language = words.DefaultLanguage
+	}
+	ws.Letters = make(map[rune]guess.Correctness)
+	for _, g := range ws.Guesses {
+		ws.updateUsedLetters(g)
+	}
+	if len(ws.Boards) > 0 {
+		for _, attempt := range ws.Attempts {
+			ws.updateRuledOut(attempt)
+		}
+	}
+	if ws.Adversarial {
+		ws.restoreCandidates()
+	}
+	return nil
+}
+
 // Guess attempts to guess with the input word, against the solution, with Worlde
 // rules. This assumes that the input exists in the `words/wordbank.go` list of valid
 // guesses.
@@ -145,38 +386,82 @@ func (ws *WordleSession) IsSolved() bool {
 // session by appending the new guess, updating the colored letters, and updating the
 // flag that determines whether or not the solution has been guessed correctly.
 // This function returns an error in the scenario where the given word argument
-// has already been used in this game session.
+// has already been used in this game session, when the word isn't the same length
+// as the solution, or when the session is in hard mode and the word doesn't use all
+// of the hints revealed so far. In an adversarial session, the solution can change
+// before the guess is scored, see dodge.
 func (ws *WordleSession) Guess(word string) error {
+	if err := ws.CheckLength(word); err != nil {
+		return err
+	}
 	for _, attempt := range ws.Attempts {
 		if attempt == word {
 			return errors.New(word + " has already been guessed in this player's session")
 		}
 	}
+	if len(ws.Boards) > 0 {
+		return ws.guessBoards(word)
+	}
+	if ws.HardMode {
+		if err := ws.checkHardMode(word); err != nil {
+			return err
+		}
+	}
+	if ws.Adversarial {
+		ws.dodge(word)
+	}
+	guess, err := guess.ConvertToGuess(word, ws.Solution)
+	if err != nil {
+		return err
+	}
 	if word == ws.Solution {
 		ws.solved = true
 	}
 	ws.Attempts = append(ws.Attempts, word)
-	guess := guess.ConvertToGuess(word, ws.Solution)
 	ws.updateUsedLetters(guess)
 	ws.Guesses = append(ws.Guesses, guess)
 	return nil
 }
 
+// GuessAs guesses the word for the player. This is the same as Guess, except that in
+// a coop session, the guess is attributed to the player, and a player can't guess
+// twice in a row if the session takes turns.
+func (ws *WordleSession) GuessAs(player, word string) error {
+	if !ws.Coop {
+		return ws.Guess(word)
+	}
+	if ws.TakeTurns && len(ws.Guessers) > 0 && ws.Guessers[len(ws.Guessers)-1] == player {
+		return errConsecutiveGuess
+	}
+	if err := ws.Guess(word); err != nil {
+		return err
+	}
+	ws.Guessers = append(ws.Guessers, player)
+	return nil
+}
+
+// CheckLength returns an error if the word isn't the same length as the solution.
+func (ws *WordleSession) CheckLength(word string) error {
+	if n, length := utf8.RuneCountInString(word), ws.length(); n != length {
+		return fmt.Errorf("'%s' has %d letters, but this puzzle's word has %d", word, n, length)
+	}
+	return nil
+}
+
 // updateUsedLetters takes a new, valid guess and updates the used letters
 // for the game session to reflect the updated correctness of the guess.
 // this is simplified because the Guess struct already has all of the used
 // letters in the guess itself, and all that needs to be done here is to
-// iterate through the used letters and update the values in the Letters array.
-// these values are already computed because the int values in the array represent
+// iterate through the used letters and update the values in the Letters map.
+// these values are already computed because the values in the array represent
 // the level of correctness, which is already calculated when converting the guessed
 // word string into the Guess struct. See guess.ConvertToGuess
-// --------------------------------------------------------------------------------
-// An open question is whether or not there should be a newly introduced state that
-// indicates that a given letter was previously guessed correctly, and then a
-// subsequent guess puts the letter in the wrong position.
+// The best state for a letter wins, so a letter that was previously guessed in the
+// correct position stays that way, even if a later guess puts it in the wrong position.
 func (ws *WordleSession) updateUsedLetters(guess *guess.Guess) {
 	for _, l := range guess.Letters {
-		idx := int(l.Char - 'a') // use 'a' for computing the index
-		ws.Letters[idx] = l.Correctness
+		if l.Correctness > ws.Letters[l.Char] {
+			ws.Letters[l.Char] = l.Correctness
+		}
 	}
 }
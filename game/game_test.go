@@ -3,8 +3,11 @@ package game
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/saxypandabear/wordlego/guess"
+	"github.com/saxypandabear/wordlego/stats"
+	"github.com/saxypandabear/wordlego/words"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,7 +21,8 @@ func TestCreateNewSession(t *testing.T) {
 	ws := NewSession(solution, allowedGuesses, puzzleNum)
 	assert.Equal(t, solution, ws.Solution)
 	assert.Equal(t, allowedGuesses, ws.MaxAllowedGuesses)
-	assert.Equal(t, make([]int, 26), ws.Letters)
+	assert.Equal(t, words.DefaultLanguage, ws.Language)
+	assert.Empty(t, ws.Letters)
 	assert.Equal(t, make([]*guess.Guess, 0, allowedGuesses), ws.Guesses)
 }
 
@@ -46,10 +50,105 @@ func TestGuessRepeatWord(t *testing.T) {
 	assert.Len(t, ws.Attempts, 2)
 }
 
+func TestGuessWrongLength(t *testing.T) {
+	ws := testSetup()
+	assert.EqualError(t, ws.Guess("part"), "'part' has 4 letters, but this puzzle's word has 5")
+	assert.EqualError(t, ws.Guess("parted"), "'parted' has 6 letters, but this puzzle's word has 5")
+	assert.Empty(t, ws.Attempts)
+	assert.Empty(t, ws.Guesses)
+}
+
+func TestOtherLengths(t *testing.T) {
+	ws := NewSession("garden", allowedGuesses, puzzleNum)
+	assert.NoError(t, ws.Guess("banner"))
+	assert.Equal(t, guess.Correct, ws.Letters['a'])
+	assert.Equal(t, guess.Present, ws.Letters['n'])
+	assert.NoError(t, ws.Guess("garden"))
+	assert.True(t, ws.IsSolved())
+	assert.True(t, strings.HasPrefix(ws.PrintGame(true), "```ansi\nWordle 1 (6 letters): 2/6\n"))
+
+	// only the usual five letter puzzle counts as the daily puzzle
+	ws = NewSession("garden", allowedGuesses, words.DetermineWordForDay(time.Now()))
+	_ = ws.Guess("garden")
+	assert.False(t, ws.Result("player", time.Now()).Daily)
+}
+
+func TestOtherLanguages(t *testing.T) {
+	ws := NewSession("küche", allowedGuesses, puzzleNum)
+	ws.Language = words.German.Code
+	assert.EqualError(t, ws.Guess("küch"), "'küch' has 4 letters, but this puzzle's word has 5")
+	assert.NoError(t, ws.Guess("glück"))
+	assert.Equal(t, guess.Present, ws.Letters['ü'])
+	assert.Equal(t, guess.Absent, ws.Letters['g'])
+	assert.True(t, strings.HasPrefix(ws.PrintGame(true), "```ansi\nWordle 1 (Deutsch): 1/6\n"))
+	assert.Contains(t, ws.FormatUsedLetters(), guess.YellowText+"ü")
+
+	// only the English puzzle counts as the daily puzzle
+	ws = NewSession("küche", allowedGuesses, words.DetermineWordForDay(time.Now()))
+	ws.Language = words.German.Code
+	_ = ws.Guess("küche")
+	assert.False(t, ws.Result("player", time.Now()).Daily)
+}
+
+func TestGuessAs(t *testing.T) {
+	// solo sessions don't keep track of who guessed
+	ws := testSetup()
+	assert.NoError(t, ws.GuessAs("alice", "hello"))
+	assert.NoError(t, ws.GuessAs("alice", "pants"))
+	assert.Empty(t, ws.Guessers)
+
+	ws = testSetup()
+	ws.Coop = true
+	assert.NoError(t, ws.GuessAs("alice", "hello"))
+	assert.NoError(t, ws.GuessAs("alice", "pants"))
+	assert.EqualError(t, ws.GuessAs("bob", "pants"), "pants has already been guessed in this player's session")
+	assert.Equal(t, []string{"alice", "alice"}, ws.Guessers)
+
+	ws.TakeTurns = true
+	assert.ErrorIs(t, ws.GuessAs("alice", "party"), errConsecutiveGuess)
+	assert.NoError(t, ws.GuessAs("bob", "party"))
+	assert.Equal(t, []string{"alice", "alice", "bob"}, ws.Guessers)
+	assert.Equal(t, "1. <@alice>\n2. <@alice>\n3. <@bob>", ws.FormatGuessers())
+	assert.True(t, strings.HasSuffix(ws.PrintGame(true), "```\n"+ws.FormatGuessers()))
+}
+
 func TestGuessUpdatesLetterCorrectness(t *testing.T) {
 	ws := testSetup()
 	_ = ws.Guess("pants")
+	assert.Equal(t, guess.Correct, ws.Letters['p'])
+	assert.Equal(t, guess.Correct, ws.Letters['a'])
+	assert.Equal(t, guess.Absent, ws.Letters['n'])
+	assert.Equal(t, guess.Correct, ws.Letters['t'])
+	assert.Equal(t, guess.Absent, ws.Letters['s'])
 
+	// the a is in the wrong position this time, but it was already found
+	_ = ws.Guess("tramp")
+	assert.Equal(t, guess.Correct, ws.Letters['a'])
+	assert.Equal(t, guess.Correct, ws.Letters['p'])
+	assert.Equal(t, guess.Correct, ws.Letters['t'])
+	assert.Equal(t, guess.Present, ws.Letters['r'])
+	assert.Equal(t, guess.Absent, ws.Letters['m'])
+
+	_ = ws.Guess("party")
+	assert.Equal(t, guess.Correct, ws.Letters['r'])
+	assert.Equal(t, guess.Unknown, ws.Letters['z']) // never guessed
+}
+
+func TestFormatUsedLetters(t *testing.T) {
+	ws := testSetup()
+	_ = ws.Guess("pants")
+	assert.Equal(t, QWERTY.Format(ws.Letters), ws.FormatUsedLetters())
+	assert.True(t, strings.HasPrefix(ws.FormatUsedLetters(), guess.DefaultText+"q "))
+	assert.Contains(t, ws.FormatUsedLetters(), guess.GreenText+"p")
+
+	ws.Keyboard = AZERTY.Name
+	assert.Equal(t, AZERTY.Format(ws.Letters), ws.FormatUsedLetters())
+	assert.True(t, strings.HasPrefix(ws.FormatUsedLetters(), guess.GreenText+"a "))
+
+	// the letters that the layout is missing for the language get their own row
+	ws.Language = words.Spanish.Code
+	assert.Equal(t, AZERTY.WithAlphabet(words.Spanish.Alphabet).Format(ws.Letters), ws.FormatUsedLetters())
+	assert.True(t, strings.HasSuffix(ws.FormatUsedLetters(), "ñ"+guess.ResetText+"\n"))
 }
 
 func TestCanPlay(t *testing.T) {
@@ -79,6 +178,64 @@ func TestSolving(t *testing.T) {
 	assert.False(t, ws.CanPlay())
 }
 
+func TestForfeit(t *testing.T) {
+	ws := testSetup()
+	ws.Guess("pants")
+	assert.True(t, ws.CanPlay())
+	ws.Forfeit()
+	assert.True(t, ws.IsForfeited())
+	assert.False(t, ws.IsSolved())
+	assert.False(t, ws.CanPlay())
+	assert.True(t, strings.HasPrefix(ws.PrintGame(true), "```ansi\nWordle 1: X/6 (forfeit)\n"))
+}
+
+func TestResult(t *testing.T) {
+	started := time.Date(2022, time.February, 1, 11, 58, 0, 0, time.UTC)
+	finished := time.Date(2022, time.February, 1, 12, 0, 0, 0, time.UTC)
+	ws := testSetup()
+	ws.HardMode = true
+	ws.Guild = "guild"
+	ws.Started = started
+	_ = ws.Guess("pants")
+	_ = ws.Guess(solution)
+	assert.Equal(t, stats.Result{
+		Player:     "player",
+		Puzzle:     puzzleNum,
+		Guesses:    2,
+		MaxGuesses: allowedGuesses,
+		HardMode:   true,
+		Outcome:    stats.Win,
+		Grid:       strings.TrimSuffix(ws.FormatEmojis(false), "\n"),
+		Guild:      "guild",
+		Daily:      false, // puzzle 1 was long before this game started
+		Language:   words.DefaultLanguage,
+		Solution:   solution,
+		Started:    started,
+		Finished:   finished,
+	}, ws.Result("player", finished))
+
+	ws = NewSession(solution, allowedGuesses, words.DetermineWordForDay(time.Now()))
+	_ = ws.Guess(solution)
+	assert.True(t, ws.Result("player", time.Now()).Daily)
+
+	// practice games are never daily, even for today's puzzle
+	ws = NewSession(solution, allowedGuesses, words.DetermineWordForDay(time.Now()))
+	ws.Practice = true
+	_ = ws.Guess(solution)
+	result := ws.Result("player", time.Now())
+	assert.False(t, result.Daily)
+	assert.True(t, result.Practice)
+
+	ws = testSetup()
+	_ = ws.Guess("pants")
+	ws.Forfeit()
+	assert.Equal(t, stats.Forfeit, ws.Result("player", finished).Outcome)
+
+	ws = NewSession(solution, 1, puzzleNum)
+	_ = ws.Guess("pants")
+	assert.Equal(t, stats.Loss, ws.Result("player", finished).Outcome)
+}
+
 func TestFormatEmojis(t *testing.T) {
 	ws := testSetup()
 	ws.Guess("pants")
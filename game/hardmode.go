@@ -0,0 +1,79 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/saxypandabear/wordlego/guess"
+)
+
+// checkHardMode verifies that the word follows the hard mode rules, given all
+// of the previous guesses in the session:
+// 1. Any letter that was revealed in the correct position must be used in that position.
+// 2. Any letter that was revealed to be in the solution must be used somewhere in the guess.
+// Letters that were revealed by hints must be used in their position too, see TakeHint.
+// If a guess revealed more than one of the same letter, the word needs to use at least
+// that many of the letter.
+// The returned error names the first rule that the word breaks, with the positions
+// checked before the letters.
+func (ws *WordleSession) checkHardMode(word string) error {
+	runes := []rune(word)
+	required := make(map[rune]int) // minimum count of each letter that has been revealed
+	var order []rune               // the revealed letters, in the order they were revealed
+	for _, g := range ws.Guesses {
+		revealed := make(map[rune]int)
+		for i, l := range g.Letters {
+			if l.Correctness == guess.Correct && (i >= len(runes) || runes[i] != l.Char) {
+				return fmt.Errorf("%s letter must be %s", ordinal(i+1), strings.ToUpper(string(l.Char)))
+			}
+			if l.Correctness == guess.Correct || l.Correctness == guess.Present {
+				revealed[l.Char]++
+			}
+		}
+		for _, l := range g.Letters {
+			if revealed[l.Char] > required[l.Char] {
+				if required[l.Char] == 0 {
+					order = append(order, l.Char)
+				}
+				required[l.Char] = revealed[l.Char]
+			}
+		}
+	}
+
+	for _, h := range ws.Hints {
+		if h.Kind == LetterHint && (h.Position >= len(runes) || string(runes[h.Position]) != h.Letter) {
+			return fmt.Errorf("%s letter must be %s", ordinal(h.Position+1), strings.ToUpper(h.Letter))
+		}
+	}
+
+	used := make(map[rune]int)
+	for _, c := range runes {
+		used[c]++
+	}
+	for _, c := range order {
+		if used[c] >= required[c] {
+			continue
+		}
+		if required[c] == 1 {
+			return fmt.Errorf("guess must contain %s", strings.ToUpper(string(c)))
+		}
+		return fmt.Errorf("guess must contain %d %s's", required[c], strings.ToUpper(string(c)))
+	}
+	return nil
+}
+
+// ordinal returns the ordinal form of a positive number, i.e. 1st, 2nd, 3rd, 4th
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+		// 11th, 12th, 13th
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHardModeRequiresCorrectLetters(t *testing.T) {
+	ws := testSetup()
+	ws.HardMode = true
+	assert.NoError(t, ws.Guess("pants"))
+
+	err := ws.Guess("parks")
+	assert.EqualError(t, err, "4th letter must be T")
+	err = ws.Guess("hello")
+	assert.EqualError(t, err, "1st letter must be P")
+	assert.Len(t, ws.Attempts, 1) // rejected guesses don't count
+
+	assert.NoError(t, ws.Guess("pasta"))
+	assert.NoError(t, ws.Guess(solution))
+	assert.True(t, ws.IsSolved())
+}
+
+func TestHardModeRequiresPresentLetters(t *testing.T) {
+	ws := testSetup()
+	ws.HardMode = true
+	assert.NoError(t, ws.Guess("tramp"))
+
+	assert.EqualError(t, ws.Guess("hello"), "guess must contain T")
+	assert.EqualError(t, ws.Guess("tough"), "guess must contain R")
+	assert.EqualError(t, ws.Guess("trout"), "guess must contain A")
+	assert.EqualError(t, ws.Guess("start"), "guess must contain P")
+	assert.NoError(t, ws.Guess("parts"))
+}
+
+func TestHardModeRequiresDuplicateLetters(t *testing.T) {
+	ws := NewSession("abbey", allowedGuesses, puzzleNum)
+	ws.HardMode = true
+	assert.NoError(t, ws.Guess("kebab"))
+
+	assert.EqualError(t, ws.Guess("abide"), "3rd letter must be B")
+	assert.EqualError(t, ws.Guess("abbot"), "guess must contain E")
+	assert.EqualError(t, ws.Guess("embay"), "guess must contain 2 B's")
+	assert.NoError(t, ws.Guess("babes"))
+}
+
+func TestHardModeDisabled(t *testing.T) {
+	ws := testSetup()
+	assert.NoError(t, ws.Guess("pants"))
+	assert.NoError(t, ws.Guess("hello"))
+	assert.False(t, strings.Contains(ws.PrintGame(true), "*"))
+
+	ws.HardMode = true
+	assert.True(t, strings.HasPrefix(ws.PrintGame(true), "```ansi\nWordle 1: 2/6*\n"))
+}
+
+func TestOrdinal(t *testing.T) {
+	expected := map[int]string{
+		1:   "1st",
+		2:   "2nd",
+		3:   "3rd",
+		4:   "4th",
+		5:   "5th",
+		8:   "8th",
+		11:  "11th",
+		12:  "12th",
+		13:  "13th",
+		21:  "21st",
+		22:  "22nd",
+		101: "101st",
+		111: "111th",
+	}
+	for n, s := range expected {
+		assert.Equal(t, s, ordinal(n))
+	}
+}
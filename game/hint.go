@@ -0,0 +1,186 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/saxypandabear/wordlego/guess"
+	"github.com/saxypandabear/wordlego/words"
+)
+
+// the kinds of hints that a player can take, see TakeHint
+const (
+	// Reveals the letter in one of the positions that hasn't been revealed yet. This is the default.
+	LetterHint string = "letter"
+	// Reveals how many words could still be the solution, given the guesses so far
+	CountHint string = "count"
+)
+
+// errors that are shown directly to the player
+var (
+	errHintBoards     = errors.New("Hints aren't available in multi-board games.")
+	errHintRace       = errors.New("Hints aren't allowed in races.")
+	errHintGuesses    = errors.New("A hint costs a guess, so you need at least two guesses left to take one.")
+	errHintAllLetters = errors.New("Every letter has already been revealed. Try a count hint instead.")
+	errHintKind       = errors.New("Pick a letter or a count hint.")
+)
+
+// TakenHint is a hint that was taken in a session, which cost the session a guess.
+type TakenHint struct {
+	Kind       string // LetterHint or CountHint
+	Position   int    // the position of the revealed letter in a letter hint, starting from 0
+	Letter     string // the revealed letter in a letter hint
+	Candidates int    // the number of words that could still be the solution in a count hint
+}
+
+// String describes the hint, to show on the board.
+func (h TakenHint) String() string {
+	if h.Kind == LetterHint {
+		return fmt.Sprintf("%s letter is %s", ordinal(h.Position+1), strings.ToUpper(h.Letter))
+	}
+	if h.Candidates == 1 {
+		return "1 possible word left"
+	}
+	return fmt.Sprintf("%d possible words left", h.Candidates)
+}
+
+// TakeHint reveals something about the solution in exchange for one of the session's
+// guesses. A letter hint reveals the first position whose letter hasn't been revealed
+// by a guess or another hint yet, and a count hint reveals how many words could still
+// be the solution, see consistentSolutions. In an adversarial session, the revealed
+// letter rules out the candidates that don't have it.
+// This returns an error, without taking the hint, if the session can't take one: multi-
+// board games and races don't have hints, and the session has to have at least two
+// guesses left, so that it can still make a guess after the hint.
+func (ws *WordleSession) TakeHint(kind string) (TakenHint, error) {
+	switch {
+	case len(ws.Boards) > 0:
+		return TakenHint{}, errHintBoards
+	case ws.Match != "":
+		return TakenHint{}, errHintRace
+	case ws.MaxAllowedGuesses-len(ws.Attempts) < 2:
+		return TakenHint{}, errHintGuesses
+	}
+
+	var h TakenHint
+	switch kind {
+	case LetterHint:
+		pos, ok := ws.unrevealedPosition()
+		if !ok {
+			return TakenHint{}, errHintAllLetters
+		}
+		h = TakenHint{Kind: LetterHint, Position: pos, Letter: string([]rune(ws.Solution)[pos])}
+	case CountHint:
+		h = TakenHint{Kind: CountHint, Candidates: ws.countCandidates()}
+	default:
+		return TakenHint{}, errHintKind
+	}
+
+	ws.Hints = append(ws.Hints, h)
+	ws.MaxAllowedGuesses--
+	if ws.Adversarial && h.Kind == LetterHint {
+		ws.Candidates = ws.consistentSolutions(ws.Candidates)
+	}
+	return h, nil
+}
+
+// unrevealedPosition returns the first position of the solution whose letter hasn't
+// been revealed yet, either by a guess with the letter in the correct position, or by
+// a letter hint, and whether there is one.
+func (ws *WordleSession) unrevealedPosition() (int, bool) {
+	revealed := make(map[int]bool)
+	for _, g := range ws.Guesses {
+		for i, l := range g.Letters {
+			if l.Correctness == guess.Correct {
+				revealed[i] = true
+			}
+		}
+	}
+	for _, h := range ws.Hints {
+		if h.Kind == LetterHint {
+			revealed[h.Position] = true
+		}
+	}
+	for i := 0; i < ws.length(); i++ {
+		if !revealed[i] {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// countCandidates returns how many words could still be the solution. The solution of
+// a custom puzzle might not be in the list of solutions, but it's always counted.
+func (ws *WordleSession) countCandidates() int {
+	if ws.Adversarial {
+		return len(ws.Candidates)
+	}
+	sols, _ := words.SolutionsFor(ws.Language, ws.length())
+	candidates := ws.consistentSolutions(sols)
+	for _, c := range candidates {
+		if c == ws.Solution {
+			return len(candidates)
+		}
+	}
+	return len(candidates) + 1
+}
+
+// FormatHints lists the hints that were taken in the session, one per line, to show
+// on the board.
+func (ws *WordleSession) FormatHints() string {
+	var b strings.Builder
+	for _, h := range ws.Hints {
+		b.WriteString("Hint: " + h.String() + "\n")
+	}
+	return b.String()
+}
+
+// hintSession takes a hint of the kind in the session under the ID, and saves the
+// session. This returns errNoSession if there isn't a session under the ID.
+// The caller must hold the lock for the ID, see playerLocks.
+func hintSession(id, kind string) (*WordleSession, TakenHint, error) {
+	sess, ok := sessions.Get(id)
+	if !ok {
+		return nil, TakenHint{}, errNoSession
+	}
+	h, err := sess.TakeHint(kind)
+	if err != nil {
+		return nil, TakenHint{}, err
+	}
+	if err = sessions.Put(id, sess); err != nil {
+		log.Printf("Exception occurred when trying to save the game session: %s\n", err.Error())
+	}
+	return sess, h, nil
+}
+
+// hint takes a hint for the player's active game, or for the channel's coop game,
+// which costs one of the game's guesses. The hint is shown on the board, and the result
+// of the game is marked as hinted when it's shared. The hint is only shown to the player
+// in a solo game, but the whole channel sees a hint that's taken for a coop game.
+func hint(s *discordgo.Session, i *discordgo.InteractionCreate, args *CommandArgs) {
+	id := sessionID(i.Interaction, args.Mode)
+	defer players.lock(id)()
+
+	sess, h, err := hintSession(id, args.HintKind)
+	if err != nil {
+		respondEphemeral(s, i, err.Error())
+		return
+	}
+	editBoard(s, sess) // show the hint and the guesses that are left on the board, if it's still around
+
+	left := sess.MaxAllowedGuesses - len(sess.Attempts)
+	if !sess.Coop {
+		respondEphemeral(s, i, fmt.Sprintf("Hint: %s. You have %d guesses left.", h, left))
+		return
+	}
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:         fmt.Sprintf("<@%s> took a hint for the channel! %s. The channel has %d guesses left.", playerID(i.Interaction), h, left),
+			AllowedMentions: &discordgo.MessageAllowedMentions{}, // don't ping the player
+		},
+	})
+}
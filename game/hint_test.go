@@ -0,0 +1,170 @@
+package game
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saxypandabear/wordlego/stats"
+	"github.com/saxypandabear/wordlego/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeLetterHint(t *testing.T) {
+	ws := NewSession("party", allowedGuesses, puzzleNum)
+	assert.NoError(t, ws.Guess("pants"))
+
+	// p, a and t were already revealed by the guess
+	h, err := ws.TakeHint(LetterHint)
+	assert.NoError(t, err)
+	assert.Equal(t, TakenHint{Kind: LetterHint, Position: 2, Letter: "r"}, h)
+	assert.Equal(t, "3rd letter is R", h.String())
+	assert.Equal(t, 5, ws.MaxAllowedGuesses)
+
+	h, err = ws.TakeHint(LetterHint)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, h.Position)
+	_, err = ws.TakeHint(LetterHint)
+	assert.ErrorIs(t, err, errHintAllLetters)
+	assert.Equal(t, 4, ws.MaxAllowedGuesses)
+
+	board := ws.PrintGame(false)
+	assert.True(t, strings.HasPrefix(board, "```ansi\nWordle 1: 1/4 (hinted)\n"))
+	assert.Contains(t, board, "Hint: 3rd letter is R\nHint: 5th letter is Y\n")
+	shared := ws.PrintGame(true)
+	assert.NotContains(t, shared, "Hint:")
+
+	ws.Forfeit()
+	assert.True(t, strings.HasPrefix(ws.PrintGame(true), "```ansi\nWordle 1: X/4 (forfeit, hinted)\n"))
+	result := ws.Result("player", ws.Started)
+	assert.True(t, result.Hinted)
+	// the hints count as guesses
+	assert.Equal(t, 3, result.Guesses)
+	assert.Equal(t, 6, result.MaxGuesses)
+}
+
+func TestHintedResultIsNotAhead(t *testing.T) {
+	today := words.DetermineWordForDay(time.Now())
+	play := func(player string, guesses []string, hints int) stats.Result {
+		ws := NewSession(words.Solutions[today-1], allowedGuesses, today)
+		ws.Guild = "guild"
+		for n := 0; n < hints; n++ {
+			_, err := ws.TakeHint(CountHint)
+			assert.NoError(t, err)
+		}
+		for _, g := range guesses {
+			assert.NoError(t, ws.Guess(g))
+		}
+		assert.True(t, ws.IsSolved())
+		return ws.Result(player, ws.Started.Add(time.Minute))
+	}
+	// the allowed words are never solutions, so they're never today's word either
+	sol, misses := words.Solutions[today-1], words.AllowedWords[:3]
+	// the hinted player solved it in fewer attempts, and quicker, but the hints cost them
+	hinted := play("hinted", []string{misses[0], misses[1], sol}, 2)
+	hinted.Finished = hinted.Started.Add(time.Second)
+	honest := play("honest", []string{misses[0], misses[1], misses[2], sol}, 0)
+	assert.Equal(t, 5, hinted.Guesses)
+
+	standings := stats.Leaderboard([]stats.Result{hinted, honest}, "guild", today, stats.Today)
+	assert.Equal(t, "honest", standings[0].Player)
+	st := stats.Compute([]stats.Result{hinted})
+	assert.Equal(t, 1, st.Distribution[4])
+}
+
+func TestTakeCountHint(t *testing.T) {
+	ws := NewSession(words.Solutions[0], allowedGuesses, puzzleNum)
+	h, err := ws.TakeHint(CountHint)
+	assert.NoError(t, err)
+	assert.Equal(t, len(words.Solutions), h.Candidates)
+
+	assert.NoError(t, ws.Guess("crane"))
+	h, err = ws.TakeHint(CountHint)
+	assert.NoError(t, err)
+	assert.Less(t, h.Candidates, len(words.Solutions))
+	assert.GreaterOrEqual(t, h.Candidates, 1)
+	for _, c := range ws.consistentSolutions(words.Solutions) {
+		assert.Equal(t, pattern("crane", c), pattern("crane", ws.Solution))
+	}
+	assert.Equal(t, "1 possible word left", TakenHint{Kind: CountHint, Candidates: 1}.String())
+
+	// the word of a custom puzzle always counts, even if it isn't a solution
+	ws = NewSession("zymes", allowedGuesses, 0)
+	h, err = ws.TakeHint(CountHint)
+	assert.NoError(t, err)
+	assert.Equal(t, len(words.Solutions)+1, h.Candidates)
+}
+
+func TestTakeHintErrors(t *testing.T) {
+	ws := NewSession("party", 2, puzzleNum)
+	_, err := ws.TakeHint("nope")
+	assert.ErrorIs(t, err, errHintKind)
+	assert.NoError(t, ws.Guess("pants"))
+	_, err = ws.TakeHint(LetterHint)
+	assert.ErrorIs(t, err, errHintGuesses)
+	assert.Empty(t, ws.Hints)
+	assert.Equal(t, 2, ws.MaxAllowedGuesses)
+
+	ws = NewSession("party", allowedGuesses, puzzleNum)
+	ws.Match = "match"
+	_, err = ws.TakeHint(LetterHint)
+	assert.ErrorIs(t, err, errHintRace)
+
+	multi := newMultiSession([]string{"party", "beams"}, words.DefaultLanguage, 7, puzzleNum)
+	_, err = multi.TakeHint(LetterHint)
+	assert.ErrorIs(t, err, errHintBoards)
+}
+
+func TestHintHardMode(t *testing.T) {
+	ws := NewSession("party", allowedGuesses, puzzleNum)
+	ws.HardMode = true
+	assert.NoError(t, ws.Guess("pants"))
+	_, err := ws.TakeHint(LetterHint)
+	assert.NoError(t, err)
+	assert.EqualError(t, ws.Guess("pasty"), "3rd letter must be R")
+	assert.NoError(t, ws.Guess("party"))
+}
+
+func TestAdversarialHint(t *testing.T) {
+	ws, err := newAdversarialSession(words.DefaultLanguage, words.DefaultLength, allowedGuesses)
+	assert.NoError(t, err)
+	h, err := ws.TakeHint(LetterHint)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, h.Position)
+	for _, c := range ws.Candidates {
+		assert.True(t, strings.HasPrefix(c, h.Letter))
+	}
+	count := len(ws.Candidates)
+	assert.Less(t, count, len(words.Solutions))
+
+	h, err = ws.TakeHint(CountHint)
+	assert.NoError(t, err)
+	assert.Equal(t, count, h.Candidates)
+
+	// the hints are saved, so the candidates are rebuilt with them
+	data, err := json.Marshal(ws)
+	assert.NoError(t, err)
+	var restored WordleSession
+	assert.NoError(t, json.Unmarshal(data, &restored))
+	assert.Equal(t, ws.Hints, restored.Hints)
+	assert.Equal(t, ws.Candidates, restored.Candidates)
+}
+
+func TestHintSession(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	_, _, err := hintSession("player", LetterHint)
+	assert.ErrorIs(t, err, errNoSession)
+
+	args := &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage}
+	_, err = startSession("player", "player", args)
+	assert.NoError(t, err)
+	sess, h, err := hintSession("player", LetterHint)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, h.Position)
+	assert.Equal(t, allowedGuesses-1, sess.MaxAllowedGuesses)
+	saved, ok := sessions.Get("player")
+	assert.True(t, ok)
+	assert.Equal(t, sess.Hints, saved.Hints)
+	assert.Equal(t, allowedGuesses-1, saved.MaxAllowedGuesses)
+}
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"strings"
+
+	"github.com/saxypandabear/wordlego/guess"
+	"github.com/saxypandabear/wordlego/words"
+)
+
+// KeyboardLayout describes how the used letters are laid out when displaying
+// a game session, so that it resembles the keyboard that the player is used to.
+type KeyboardLayout struct {
+	Name string   // the name that the player picks the layout by
+	Rows []string // the keys in each row of the keyboard, from top to bottom
+}
+
+var (
+	// American QWERTY keyboard. This is the default layout.
+	QWERTY = KeyboardLayout{
+		Name: "qwerty",
+		Rows: []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"},
+	}
+	// French AZERTY keyboard
+	AZERTY = KeyboardLayout{
+		Name: "azerty",
+		Rows: []string{"azertyuiop", "qsdfghjklm", "wxcvbn"},
+	}
+	// German QWERTZ keyboard
+	QWERTZ = KeyboardLayout{
+		Name: "qwertz",
+		Rows: []string{"qwertzuiop", "asdfghjkl", "yxcvbnm"},
+	}
+	// Spanish QWERTY keyboard, with ñ
+	SpanishQWERTY = KeyboardLayout{
+		Name: "qwerty-es",
+		Rows: []string{"qwertyuiop", "asdfghjklñ", "zxcvbnm"},
+	}
+	// German QWERTZ keyboard, with umlauts
+	GermanQWERTZ = KeyboardLayout{
+		Name: "qwertz-de",
+		Rows: []string{"qwertzuiopü", "asdfghjklöä", "yxcvbnm"},
+	}
+	// Portuguese QWERTY keyboard, with ç
+	PortugueseQWERTY = KeyboardLayout{
+		Name: "qwerty-pt",
+		Rows: []string{"qwertyuiop", "asdfghjklç", "zxcvbnm"},
+	}
+)
+
+// KeyboardLayouts are all of the layouts that a player can choose from, keyed by name.
+var KeyboardLayouts = map[string]KeyboardLayout{
+	QWERTY.Name:           QWERTY,
+	AZERTY.Name:           AZERTY,
+	QWERTZ.Name:           QWERTZ,
+	SpanishQWERTY.Name:    SpanishQWERTY,
+	GermanQWERTZ.Name:     GermanQWERTZ,
+	PortugueseQWERTY.Name: PortugueseQWERTY,
+}
+
+// the layouts that are used for each language when the player doesn't pick one.
+// languages that aren't listed here default to QWERTY.
+var languageKeyboards = map[string]KeyboardLayout{
+	words.Spanish.Code:    SpanishQWERTY,
+	words.German.Code:     GermanQWERTZ,
+	words.Portuguese.Code: PortugueseQWERTY,
+}
+
+// DefaultKeyboardLayout returns the layout that the language is usually typed
+// with, which is QWERTY for English.
+func DefaultKeyboardLayout(language string) KeyboardLayout {
+	if layout, ok := languageKeyboards[language]; ok {
+		return layout
+	}
+	return QWERTY
+}
+
+// GetKeyboardLayout looks up the layout with the given name, and defaults to
+// QWERTY if there is no such layout.
+func GetKeyboardLayout(name string) KeyboardLayout {
+	if layout, ok := KeyboardLayouts[name]; ok {
+		return layout
+	}
+	return QWERTY
+}
+
+// Format returns an ANSI formatted string that shows each key on the keyboard,
+// highlighted with the correctness of the letter from the given letter states.
+// Each row is indented a bit more than the last one, like on a real keyboard.
+// The letter states are expected to be keyed the same way as WordleSession.Letters.
+func (kl KeyboardLayout) Format(letters map[rune]guess.Correctness) string {
+	var b strings.Builder
+	for i, row := range kl.Rows {
+		b.WriteString(strings.Repeat(" ", i))
+		for j, c := range row {
+			if j > 0 {
+				b.WriteString(" ")
+			}
+			l := guess.Letter{
+				Char:        c,
+				Correctness: letters[c],
+			}
+			b.WriteString(l.ColoredText())
+		}
+		b.WriteString(guess.ResetText + "\n")
+	}
+	return b.String()
+}
+
+// WithAlphabet returns the layout with an extra row for any letters of the alphabet
+// that the layout doesn't have keys for, so that every letter that can be guessed
+// is shown, i.e. the umlauts when playing in German with a QWERTY layout.
+func (kl KeyboardLayout) WithAlphabet(alphabet string) KeyboardLayout {
+	keys := strings.Join(kl.Rows, "")
+	var missing strings.Builder
+	for _, c := range alphabet {
+		if !strings.ContainsRune(keys, c) {
+			missing.WriteRune(c)
+		}
+	}
+	if missing.Len() == 0 {
+		return kl
+	}
+	rows := append(append([]string(nil), kl.Rows...), missing.String())
+	return KeyboardLayout{Name: kl.Name, Rows: rows}
+}
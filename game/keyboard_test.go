@@ -0,0 +1,75 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/saxypandabear/wordlego/guess"
+	"github.com/saxypandabear/wordlego/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetKeyboardLayout(t *testing.T) {
+	assert.Equal(t, QWERTY, GetKeyboardLayout("qwerty"))
+	assert.Equal(t, AZERTY, GetKeyboardLayout("azerty"))
+	assert.Equal(t, QWERTZ, GetKeyboardLayout("qwertz"))
+	assert.Equal(t, GermanQWERTZ, GetKeyboardLayout("qwertz-de"))
+	assert.Equal(t, QWERTY, GetKeyboardLayout("dvorak"))
+	assert.Equal(t, QWERTY, GetKeyboardLayout(""))
+}
+
+func TestDefaultKeyboardLayout(t *testing.T) {
+	assert.Equal(t, QWERTY, DefaultKeyboardLayout(words.English.Code))
+	assert.Equal(t, SpanishQWERTY, DefaultKeyboardLayout(words.Spanish.Code))
+	assert.Equal(t, GermanQWERTZ, DefaultKeyboardLayout(words.German.Code))
+	assert.Equal(t, PortugueseQWERTY, DefaultKeyboardLayout(words.Portuguese.Code))
+	assert.Equal(t, QWERTY, DefaultKeyboardLayout(""))
+}
+
+func TestKeyboardLayoutsHaveEveryLetter(t *testing.T) {
+	for name, layout := range KeyboardLayouts {
+		t.Run(name, func(t *testing.T) {
+			keys := []rune(strings.Join(layout.Rows, ""))
+			for c := 'a'; c <= 'z'; c++ {
+				assert.Contains(t, keys, c)
+			}
+			seen := make(map[rune]bool, len(keys))
+			for _, c := range keys {
+				assert.False(t, seen[c], "%c has more than one key", c)
+				seen[c] = true
+			}
+		})
+	}
+
+	// the default layouts have a key for every letter of their language
+	for code, lang := range words.Languages {
+		t.Run(code, func(t *testing.T) {
+			layout := DefaultKeyboardLayout(code)
+			assert.Equal(t, layout, layout.WithAlphabet(lang.Alphabet))
+		})
+	}
+}
+
+func TestKeyboardWithAlphabet(t *testing.T) {
+	layout := QWERTY.WithAlphabet(words.German.Alphabet)
+	assert.Equal(t, []string{"qwertyuiop", "asdfghjkl", "zxcvbnm", "äöü"}, layout.Rows)
+	assert.Equal(t, []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}, QWERTY.Rows) // unchanged
+	assert.Equal(t, QWERTY, QWERTY.WithAlphabet(words.English.Alphabet))
+}
+
+// untried letters and eliminated letters should be displayed differently
+func TestKeyboardFormat(t *testing.T) {
+	letters := map[rune]guess.Correctness{
+		'q': guess.Correct,
+		'w': guess.Present,
+		'a': guess.Absent,
+	}
+	layout := KeyboardLayout{
+		Name: "test",
+		Rows: []string{"qwe", "as"},
+	}
+
+	expected := guess.GreenText + "q " + guess.YellowText + "w " + guess.DefaultText + "e" + guess.ResetText + "\n" +
+		" " + guess.AbsentText + "a " + guess.DefaultText + "s" + guess.ResetText + "\n"
+	assert.Equal(t, expected, layout.Format(letters))
+}
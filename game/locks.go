@@ -0,0 +1,75 @@
+package game
+
+import (
+	"sort"
+	"sync"
+)
+
+// keep track of the lock for each player that is currently interacting with the bot
+var players = newPlayerLocks()
+
+// playerLocks hands out a lock per player ID, so that interactions from the same
+// player are handled one at a time, while interactions from different players
+// don't block each other. Discord handlers run in their own goroutines, so
+// without this, two quick guesses from one player would race on the same session.
+type playerLocks struct {
+	mu    sync.Mutex
+	locks map[string]*playerLock
+}
+
+type playerLock struct {
+	sync.Mutex
+	refs int // number of goroutines holding or waiting on the lock
+}
+
+func newPlayerLocks() *playerLocks {
+	return &playerLocks{
+		locks: make(map[string]*playerLock),
+	}
+}
+
+// lock blocks until the given player's lock is acquired, and returns the function
+// that releases it. The lock is cleaned up once nothing is holding or waiting on it,
+// so this doesn't keep growing with every player that has ever played.
+// Example:
+// defer players.lock(id)()
+func (p *playerLocks) lock(id string) func() {
+	p.mu.Lock()
+	l, ok := p.locks[id]
+	if !ok {
+		l = &playerLock{}
+		p.locks[id] = l
+	}
+	l.refs++
+	p.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		p.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(p.locks, id)
+		}
+		p.mu.Unlock()
+	}
+}
+
+// lockPlayers blocks until the locks for all of the given players are acquired, and
+// returns the function that releases them. The locks are always acquired in the same
+// order, so that two interactions that lock the same players can't deadlock.
+// Example:
+// defer lockPlayers(challenger, opponent)()
+func lockPlayers(ids ...string) func() {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	unlocks := make([]func(), len(sorted))
+	for i, id := range sorted {
+		unlocks[i] = players.lock(id)
+	}
+	return func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+}
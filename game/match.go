@@ -0,0 +1,497 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/saxypandabear/wordlego/config"
+	"github.com/saxypandabear/wordlego/words"
+)
+
+// Button on a race's scoreboard that shows the player their board
+const MatchPlayButtonID = "wordle-match-play-button"
+
+// errRaceBusy is returned when the member that was challenged tries to join the race
+// while they're already playing another game
+var errRaceBusy = errors.New("You already have an active game. Finish it or use /wordle stop, then press Play again to join the race.")
+
+// errNoRacePuzzles is returned when there aren't any puzzles that a race can be played with
+var errNoRacePuzzles = errors.New("There aren't any puzzles to race with in this server. Try another length or language.")
+
+// Match is a race between two players, who each play their own session for the same
+// solution at the same time. The match owns both of the sessions, so that its scoreboard
+// can show both players' progress, even once a session is over and has been removed
+// from the session store.
+// The opponent's session is only saved once they join the race by pressing Play, so
+// that nobody can be signed up for a game, or have one recorded, without agreeing to it.
+// Matches are only kept in memory, so a race that is in progress when the bot restarts
+// carries on as two separate games.
+type Match struct {
+	ID       string                    // ID of the interaction that started the match
+	Players  []string                  // IDs of the challenger and the opponent, in that order
+	Sessions map[string]*WordleSession // the session of each player, keyed by player ID
+	Finished map[string]time.Time      // when each player's session ended, keyed by player ID
+	Joined   map[string]bool           // whether each player has started playing, keyed by player ID, see join
+	Channel  string                    // ID of the channel that the scoreboard is posted in
+	Message  string                    // ID of the scoreboard message
+}
+
+// finish records when the player's session ended, if it has ended and this is the
+// first time that it's been recorded.
+func (m *Match) finish(player string, now time.Time) {
+	if _, done := m.Finished[player]; done || m.Sessions[player].CanPlay() {
+		return
+	}
+	m.Finished[player] = now
+}
+
+// Over returns whether both of the players are done playing. An opponent that still
+// hasn't joined by the time that the challenger is done can't join anymore, so the
+// match is settled without them.
+func (m *Match) Over() bool {
+	for _, p := range m.Players {
+		if m.Joined[p] && m.Sessions[p].CanPlay() {
+			return false
+		}
+	}
+	return true
+}
+
+// Winner returns the player that won the match, and whether anyone won. Only players
+// that solved the puzzle can win, and the winner is the one that took the fewest
+// guesses, with the quickest solve breaking ties. This should only be called once
+// the match is over, see Over.
+func (m *Match) Winner() (string, bool) {
+	best, tied := "", false
+	for _, p := range m.Players {
+		if !m.Sessions[p].IsSolved() {
+			continue
+		}
+		if best == "" {
+			best = p
+			continue
+		}
+		switch c := m.compare(p, best); {
+		case c < 0:
+			best, tied = p, false
+		case c == 0:
+			tied = true
+		}
+	}
+	return best, best != "" && !tied
+}
+
+// compare orders two players that both solved the puzzle, by the number of guesses
+// and then by how long they took, from when their session started to when they
+// finished, so that an opponent that joined later isn't behind for it. The result is
+// negative if a did better than b, positive if b did better than a, and 0 if they did
+// exactly as well.
+func (m *Match) compare(a, b string) int {
+	if diff := len(m.Sessions[a].Attempts) - len(m.Sessions[b].Attempts); diff != 0 {
+		return diff
+	}
+	switch ta, tb := m.Finished[a].Sub(m.Sessions[a].Started), m.Finished[b].Sub(m.Sessions[b].Started); {
+	case ta < tb:
+		return -1
+	case tb < ta:
+		return 1
+	}
+	return 0
+}
+
+// Scoreboard returns the message that shows the progress of both players, using
+// only the emoji grids so that neither player can see the other's letters. Once
+// the match is over, it declares the winner and reveals the solution.
+func (m *Match) Scoreboard() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("**Wordle race** between <@%s> and <@%s>\n", m.Players[0], m.Players[1]))
+	for _, p := range m.Players {
+		sess := m.Sessions[p]
+		status := "playing"
+		switch {
+		case !m.Joined[p] && m.Over():
+			status = "didn't join"
+		case !m.Joined[p]:
+			status = "hasn't joined yet"
+		case sess.IsSolved():
+			status = "solved"
+		case sess.IsForfeited():
+			status = "gave up"
+		case !sess.CanPlay():
+			status = "out of guesses"
+		}
+		b.WriteString(fmt.Sprintf("\n<@%s>: %d/%d, %s\n", p, len(sess.Attempts), sess.MaxAllowedGuesses, status))
+		b.WriteString(sess.FormatEmojis(false))
+	}
+	if !m.Over() {
+		return b.String()
+	}
+
+	b.WriteString("\n")
+	winner, won := m.Winner()
+	switch {
+	case won:
+		b.WriteString(fmt.Sprintf("<@%s> wins!", winner))
+	case m.Sessions[m.Players[0]].IsSolved():
+		b.WriteString("It's a tie!") // the challenger solved it without winning, so both did equally well
+	default:
+		b.WriteString("Nobody solved it!")
+	}
+	b.WriteString(fmt.Sprintf(" The word was ||%s||", m.Sessions[m.Players[0]].Solution))
+	return b.String()
+}
+
+// scoreboardComponents returns the Play button for the scoreboard, which is removed
+// once the match is over.
+func scoreboardComponents(m *Match) []discordgo.MessageComponent {
+	if m.Over() {
+		return []discordgo.MessageComponent{}
+	}
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "Play",
+					Style:    discordgo.PrimaryButton,
+					CustomID: MatchPlayButtonID,
+				},
+			},
+		},
+	}
+}
+
+// keep track of the matches that are in progress
+var matches = newMatchRegistry()
+
+// matchRegistry keeps track of the matches that are in progress, keyed by match ID.
+// The registry only guards its maps. Changes to a match, including its Channel and
+// Message, are guarded by the match's lock, see lockMatch, so the registry keeps its
+// own record of the scoreboard messages, see posted.
+type matchRegistry struct {
+	mu       sync.Mutex
+	matches  map[string]*Match
+	messages map[string]string // the match IDs, keyed by the ID of their scoreboard message
+}
+
+func newMatchRegistry() *matchRegistry {
+	return &matchRegistry{
+		matches:  make(map[string]*Match),
+		messages: make(map[string]string),
+	}
+}
+
+func (r *matchRegistry) get(id string) (*Match, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m, ok := r.matches[id]
+	return m, ok
+}
+
+// byMessage returns the match whose scoreboard is the message with the ID.
+func (r *matchRegistry) byMessage(message string) (*Match, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m, ok := r.matches[r.messages[message]]
+	return m, ok
+}
+
+// posted records that the scoreboard of the match with the ID is the message, see byMessage.
+func (r *matchRegistry) posted(id, message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages[message] = id
+}
+
+func (r *matchRegistry) put(m *Match) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.matches[m.ID] = m
+}
+
+func (r *matchRegistry) delete(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.matches, id)
+	for message, match := range r.messages {
+		if match == id {
+			delete(r.messages, message)
+		}
+	}
+}
+
+// lockMatch blocks until the lock for the match that the session under the ID is part
+// of is acquired, and returns the function that releases it. Sessions that aren't part
+// of a match don't need any more locking, so this doesn't lock anything for them.
+// Anything that changes a session in a match holds the match's lock, so that the
+// scoreboard can read both of the sessions. The caller must hold the lock for the ID.
+// Example:
+// defer players.lock(id)()
+// defer lockMatch(id)()
+func lockMatch(id string) func() {
+	if sess, ok := sessions.Get(id); ok && sess.Match != "" {
+		return players.lock("match:" + sess.Match)
+	}
+	return func() {}
+}
+
+// the source of the random puzzles for races. rand.Rand isn't safe to use from
+// multiple goroutines, so it's guarded by a lock.
+var (
+	randomMu sync.Mutex
+	random   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// randomPuzzle picks one of the puzzles numbered 1 to n at random.
+func randomPuzzle(n int) int {
+	randomMu.Lock()
+	defer randomMu.Unlock()
+	return random.Intn(n) + 1
+}
+
+// racePuzzle picks a random puzzle for a race, out of the puzzles for the language and
+// length from before today's. The word is revealed to the whole channel once the race is
+// over, so it can't be one that's still to come. The guild's range of puzzles is respected
+// too. This returns errNoRacePuzzles if there aren't any puzzles to pick from.
+func racePuzzle(language string, length int, cfg config.GuildConfig, today int) (int, error) {
+	count, err := words.PuzzleCount(language, length)
+	if err != nil {
+		return 0, err
+	}
+	var allowed []int
+	for n := 1; n <= count && n < today; n++ {
+		if cfg.AllowsPuzzle(n, today) {
+			allowed = append(allowed, n)
+		}
+	}
+	if len(allowed) == 0 {
+		return 0, errNoRacePuzzles
+	}
+	return allowed[randomPuzzle(len(allowed))-1], nil
+}
+
+// startMatch creates a session for each of the players with the same solution, and
+// the match that owns them. Only the challenger's session is saved, since the opponent
+// has to join the race first, see join. This returns errActiveSession if the challenger
+// is already playing.
+// The caller must hold the locks for both of the players, see lockPlayers.
+func startMatch(id, guild, challenger, opponent string, args *CommandArgs) (*Match, error) {
+	if _, exists := sessions.Get(challenger); exists {
+		return nil, errActiveSession
+	}
+
+	sol, err := words.GetSolution(args.Language, args.Length, args.PuzzleNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a solution for the race: %w", err)
+	}
+
+	m := &Match{
+		ID:       id,
+		Players:  []string{challenger, opponent},
+		Sessions: make(map[string]*WordleSession, 2),
+		Finished: make(map[string]time.Time, 2),
+		Joined:   map[string]bool{challenger: true},
+	}
+	for _, p := range m.Players {
+		sess := NewSession(sol, args.MaxGuesses, args.PuzzleNum)
+		sess.Language = args.Language
+		sess.HardMode = args.HardMode
+		sess.Match = m.ID
+		sess.Guild = guild
+		// the opponent didn't get to pick a keyboard
+		sess.Keyboard = DefaultKeyboardLayout(args.Language).Name
+		if p == challenger {
+			sess.Keyboard = args.Keyboard
+		}
+		m.Sessions[p] = sess
+	}
+	if err = sessions.Put(challenger, m.Sessions[challenger]); err != nil {
+		return nil, fmt.Errorf("failed to save the game session: %w", err)
+	}
+	matches.put(m)
+	return m, nil
+}
+
+// join starts the player's session in the match, for when the opponent accepts the
+// challenge. Their clock starts when they join, rather than when they were challenged.
+// This returns errRaceBusy if the player is already playing another game.
+// The caller must hold the player's lock and the match's lock, see lockMatch.
+func (m *Match) join(player string, now time.Time) error {
+	if _, exists := sessions.Get(player); exists {
+		return errRaceBusy
+	}
+	sess := m.Sessions[player]
+	sess.Started = now.UTC().Round(0)
+	if err := sessions.Put(player, sess); err != nil {
+		return fmt.Errorf("failed to save the game session: %w", err)
+	}
+	m.Joined[player] = true
+	return nil
+}
+
+// endMatch removes the match and the sessions of the players that joined it, for when
+// the match couldn't be started.
+func endMatch(m *Match) {
+	matches.delete(m.ID)
+	for _, p := range m.Players {
+		if m.Joined[p] {
+			sessions.Delete(p)
+		}
+	}
+}
+
+// challenge starts a race between the player and the opponent that they picked, with
+// a random puzzle, see racePuzzle. The challenger gets their board right away, and the scoreboard is
+// posted in the channel with a Play button, which the opponent presses to join the race.
+func challenge(s *discordgo.Session, i *discordgo.InteractionCreate, args *CommandArgs) {
+	if i.GuildID == "" {
+		respondEphemeral(s, i, "Races can only be played in a server.")
+		return
+	}
+	challenger := playerID(i.Interaction)
+	opponent := args.Opponent
+	switch {
+	case opponent == "":
+		respondEphemeral(s, i, "Pick a member to race with the opponent option.")
+		return
+	case opponent == challenger:
+		respondEphemeral(s, i, "You can't race yourself.")
+		return
+	}
+	if resolved := i.ApplicationCommandData().Resolved; resolved != nil {
+		if u, ok := resolved.Users[opponent]; ok && u.Bot {
+			respondEphemeral(s, i, "Bots can't play Wordle.")
+			return
+		}
+	}
+	defer lockPlayers(challenger, opponent)()
+
+	puzzle, err := racePuzzle(args.Language, args.Length, configs.Get(i.GuildID), words.DetermineWordForDay(time.Now()))
+	if err != nil {
+		respondEphemeral(s, i, err.Error())
+		return
+	}
+	args.PuzzleNum = puzzle
+	m, err := startMatch(i.ID, i.GuildID, challenger, opponent, args)
+	if errors.Is(err, errActiveSession) {
+		respondEphemeral(s, i, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("Exception occurred when trying to start a race: %s\n", err.Error())
+		respondEphemeral(s, i, "An error occurred when trying to start the race. Contact the bot owner.")
+		return
+	}
+
+	msg, err := s.ChannelMessageSendComplex(i.ChannelID, &discordgo.MessageSend{
+		Content:         m.Scoreboard(),
+		Components:      scoreboardComponents(m),
+		AllowedMentions: &discordgo.MessageAllowedMentions{Users: []string{opponent}}, // let the opponent know
+	})
+	if err != nil {
+		log.Printf("Exception occurred when trying to post the scoreboard of a race: %s\n", err.Error())
+		endMatch(m)
+		respondEphemeral(s, i, "The race couldn't be posted in this channel.")
+		return
+	}
+	unlock := players.lock("match:" + m.ID)
+	m.Channel, m.Message = i.ChannelID, msg.ID
+	unlock()
+	matches.posted(m.ID, msg.ID)
+
+	showMatchBoard(s, i, challenger, m.Sessions[challenger])
+}
+
+// MatchPlayButton is the hook for the Play button on a race's scoreboard. It shows
+// the player their board for the race. The opponent joins the race the first time that
+// they press it, since they didn't start the race themselves.
+func MatchPlayButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	id := playerID(i.Interaction)
+	defer players.lock(id)()
+	if i.Message == nil {
+		respondEphemeral(s, i, "You don't have a game to play in this race.")
+		return
+	}
+
+	m, found := matches.byMessage(i.Message.ID)
+	if !found {
+		respondEphemeral(s, i, "You don't have a game to play in this race.")
+		return
+	}
+	defer players.lock("match:" + m.ID)()
+	if sess, ok := sessions.Get(id); ok && sess.Match == m.ID {
+		showMatchBoard(s, i, id, sess)
+		return
+	}
+	if m.Over() {
+		respondEphemeral(s, i, "This race is already over.") // the challenger finished before the opponent joined
+		return
+	}
+	if m.Sessions[id] == nil || m.Joined[id] {
+		respondEphemeral(s, i, "You don't have a game to play in this race.")
+		return
+	}
+	if err := m.join(id, time.Now()); errors.Is(err, errRaceBusy) {
+		respondEphemeral(s, i, err.Error())
+		return
+	} else if err != nil {
+		log.Printf("Exception occurred when trying to join a race: %s\n", err.Error())
+		respondEphemeral(s, i, "An error occurred when trying to join the race. Contact the bot owner.")
+		return
+	}
+	updateMatch(s, id, m.Sessions[id]) // the scoreboard shows that the opponent joined
+	showMatchBoard(s, i, id, m.Sessions[id])
+}
+
+// showMatchBoard responds to the interaction with the player's board for their
+// session in a race, and anchors the board to it.
+// The caller must hold the player's lock, see playerLocks.
+func showMatchBoard(s *discordgo.Session, i *discordgo.InteractionCreate, id string, sess *WordleSession) {
+	content, components := boardMessage(sess)
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags:      boardFlags(sess),
+			Content:    content,
+			Components: components,
+		},
+	})
+	if err != nil {
+		return
+	}
+	sess.Board = newBoard(i.Interaction, time.Now())
+	if err = sessions.Put(id, sess); err != nil {
+		log.Printf("Exception occurred when trying to save the game session: %s\n", err.Error())
+	}
+}
+
+// updateMatch brings the scoreboard of the race that the player's session is part of
+// up to date, after the session changed. The match is over once both of the players
+// are done, and the final scoreboard declares the winner.
+// The caller must hold the match's lock, see lockMatch.
+func updateMatch(s *discordgo.Session, player string, sess *WordleSession) {
+	m, ok := matches.get(sess.Match)
+	if !ok {
+		return
+	}
+	m.finish(player, time.Now())
+	if m.Over() {
+		matches.delete(m.ID)
+	}
+	content := m.Scoreboard()
+	_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+		ID:              m.Message,
+		Channel:         m.Channel,
+		Content:         &content,
+		Components:      scoreboardComponents(m),
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		log.Printf("Exception occurred when trying to update the scoreboard of a race: %s\n", err.Error())
+	}
+}
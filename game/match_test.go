@@ -0,0 +1,289 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/saxypandabear/wordlego/config"
+	"github.com/saxypandabear/wordlego/guess"
+	"github.com/saxypandabear/wordlego/stats"
+	"github.com/saxypandabear/wordlego/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartMatch(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	args := &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Keyboard: AZERTY.Name, Length: words.DefaultLength, Language: words.DefaultLanguage}
+
+	m, err := startMatch("match", "guild", "alice", "bob", args)
+	assert.NoError(t, err)
+	defer matches.delete(m.ID)
+	assert.Equal(t, []string{"alice", "bob"}, m.Players)
+	for _, p := range m.Players {
+		sess := m.Sessions[p]
+		assert.Equal(t, words.Solutions[0], sess.Solution)
+		assert.Equal(t, "match", sess.Match)
+		assert.Equal(t, "guild", sess.Guild)
+	}
+	assert.Equal(t, AZERTY.Name, m.Sessions["alice"].Keyboard)
+	assert.Equal(t, QWERTY.Name, m.Sessions["bob"].Keyboard)
+	found, ok := matches.get("match")
+	assert.True(t, ok)
+	assert.Same(t, m, found)
+
+	// only the challenger is playing until the opponent joins
+	sess, ok := sessions.Get("alice")
+	assert.True(t, ok)
+	assert.Same(t, m.Sessions["alice"], sess)
+	_, ok = sessions.Get("bob")
+	assert.False(t, ok)
+	assert.Contains(t, m.Scoreboard(), "<@bob>: 0/6, hasn't joined yet\n")
+
+	// the challenger can't be in another game, but the opponent can be challenged again
+	_, err = startMatch("other-match", "guild", "alice", "carol", args)
+	assert.ErrorIs(t, err, errActiveSession)
+	other, err := startMatch("other-match", "guild", "carol", "bob", args)
+	assert.NoError(t, err)
+	matches.delete(other.ID)
+}
+
+func TestJoinMatch(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	UseStatsStore(stats.NewMemoryStore())
+	args := &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage}
+	m, err := startMatch("match", "guild", "alice", "bob", args)
+	assert.NoError(t, err)
+	defer matches.delete(m.ID)
+	matches.posted(m.ID, "scoreboard")
+	found, ok := matches.byMessage("scoreboard")
+	assert.True(t, ok)
+	assert.Same(t, m, found)
+	_, ok = matches.byMessage("other")
+	assert.False(t, ok)
+
+	// the opponent has to finish their own game before joining
+	_, err = startSession("bob", "bob", &CommandArgs{PuzzleNum: 2, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, m.join("bob", time.Now()), errRaceBusy)
+	assert.False(t, m.Joined["bob"])
+	_, err = stopSession("bob")
+	assert.NoError(t, err)
+
+	joined := time.Now().Add(time.Minute)
+	assert.NoError(t, m.join("bob", joined))
+	assert.True(t, m.Joined["bob"])
+	sess, ok := sessions.Get("bob")
+	assert.True(t, ok)
+	assert.Equal(t, "match", sess.Match)
+	assert.Equal(t, joined.UTC().Round(0), sess.Started)
+	assert.Contains(t, m.Scoreboard(), "<@bob>: 0/6, playing\n")
+
+	// the scoreboard is forgotten along with the match
+	matches.delete(m.ID)
+	_, ok = matches.byMessage("scoreboard")
+	assert.False(t, ok)
+	assert.Empty(t, matches.messages)
+}
+
+func TestUnjoinedMatchIsNotRecorded(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	UseStatsStore(stats.NewMemoryStore())
+	args := &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage}
+	m, err := startMatch("match", "guild", "alice", "bob", args)
+	assert.NoError(t, err)
+	defer matches.delete(m.ID)
+
+	// the opponent never joined, so they have nothing to stop, and no forfeit is recorded
+	_, err = stopSession("bob")
+	assert.ErrorIs(t, err, errNoSession)
+	assert.Empty(t, results.Results("bob"))
+	_, err = startSession("bob", "bob", args)
+	assert.NoError(t, err)
+}
+
+func TestMatchSessionsAreNotDaily(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	UseStatsStore(stats.NewMemoryStore())
+	args := &CommandArgs{PuzzleNum: words.DetermineWordForDay(time.Now()), MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage}
+	m, err := startMatch("match", "guild", "alice", "bob", args)
+	assert.NoError(t, err)
+	defer matches.delete(m.ID)
+
+	sess, err := guessSession("alice", "alice", m.Sessions["alice"].Solution)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sess.PrintGame(true), "```ansi\nWordle race: 1/6\n"))
+	recorded := results.Results("alice")
+	assert.Len(t, recorded, 1)
+	assert.False(t, recorded[0].Daily)
+}
+
+func TestMatchWinner(t *testing.T) {
+	start := time.Date(2022, time.February, 1, 12, 0, 0, 0, time.UTC)
+	m := testMatch()
+	_ = m.Sessions["alice"].Guess("pants")
+	_ = m.Sessions["alice"].Guess(solution)
+	m.finish("alice", start.Add(time.Minute))
+	assert.False(t, m.Over())
+
+	// fewer guesses wins, even when finishing later
+	_ = m.Sessions["bob"].Guess(solution)
+	m.finish("bob", start.Add(2*time.Minute))
+	assert.True(t, m.Over())
+	winner, ok := m.Winner()
+	assert.True(t, ok)
+	assert.Equal(t, "bob", winner)
+
+	// the same number of guesses goes to whoever was quickest
+	m = testMatch()
+	_ = m.Sessions["bob"].Guess(solution)
+	m.finish("bob", start.Add(2*time.Minute))
+	_ = m.Sessions["alice"].Guess(solution)
+	m.finish("alice", start.Add(time.Minute))
+	winner, ok = m.Winner()
+	assert.True(t, ok)
+	assert.Equal(t, "alice", winner)
+
+	// finishing again doesn't change the finish time
+	m.finish("alice", start.Add(time.Hour))
+	assert.Equal(t, start.Add(time.Minute), m.Finished["alice"])
+
+	// an opponent that joined late is timed from when they joined, not from the challenge
+	m = testMatch()
+	_ = m.Sessions["alice"].Guess(solution)
+	m.finish("alice", start.Add(2*time.Minute))
+	m.Sessions["bob"].Started = start.Add(10 * time.Minute)
+	_ = m.Sessions["bob"].Guess(solution)
+	m.finish("bob", start.Add(11*time.Minute))
+	winner, ok = m.Winner()
+	assert.True(t, ok)
+	assert.Equal(t, "bob", winner)
+
+	// only players that solved it can win
+	m = testMatch()
+	m.Sessions["alice"].Forfeit()
+	m.finish("alice", start)
+	_ = m.Sessions["bob"].Guess("pants")
+	_ = m.Sessions["bob"].Guess(solution)
+	m.finish("bob", start.Add(time.Minute))
+	winner, ok = m.Winner()
+	assert.True(t, ok)
+	assert.Equal(t, "bob", winner)
+
+	m = testMatch()
+	m.Sessions["alice"].Forfeit()
+	m.Sessions["bob"].Forfeit()
+	_, ok = m.Winner()
+	assert.False(t, ok)
+}
+
+func TestUnjoinedMatchIsSettled(t *testing.T) {
+	m := testMatch()
+	m.Joined["bob"] = false
+	assert.Contains(t, m.Scoreboard(), "<@bob>: 0/6, hasn't joined yet\n")
+
+	// once the challenger is done, the opponent doesn't get to join anymore
+	_ = m.Sessions["alice"].Guess("pants")
+	assert.False(t, m.Over())
+	_ = m.Sessions["alice"].Guess(solution)
+	m.finish("alice", time.Now())
+	assert.True(t, m.Over())
+	winner, ok := m.Winner()
+	assert.True(t, ok)
+	assert.Equal(t, "alice", winner)
+	board := m.Scoreboard()
+	assert.Contains(t, board, "<@bob>: 0/6, didn't join\n")
+	assert.True(t, strings.HasSuffix(board, "<@alice> wins! The word was ||party||"))
+	assert.Empty(t, scoreboardComponents(m))
+
+	m = testMatch()
+	m.Joined["bob"] = false
+	m.Sessions["alice"].Forfeit()
+	assert.True(t, strings.HasSuffix(m.Scoreboard(), "Nobody solved it! The word was ||party||"))
+}
+
+func TestMatchScoreboard(t *testing.T) {
+	m := testMatch()
+	_ = m.Sessions["alice"].Guess("pants")
+	board := m.Scoreboard()
+	assert.True(t, strings.HasPrefix(board, "**Wordle race** between <@alice> and <@bob>\n"))
+	assert.Contains(t, board, "<@alice>: 1/6, playing\n"+guess.GreenSquare)
+	assert.Contains(t, board, "<@bob>: 0/6, playing\n")
+	// the letters are never shown
+	assert.NotContains(t, board, "pants")
+	assert.NotContains(t, board, solution)
+	assert.NotEmpty(t, scoreboardComponents(m))
+
+	_ = m.Sessions["alice"].Guess(solution)
+	m.finish("alice", time.Now())
+	m.Sessions["bob"].Forfeit()
+	m.finish("bob", time.Now())
+	board = m.Scoreboard()
+	assert.Contains(t, board, "<@alice>: 2/6, solved\n")
+	assert.Contains(t, board, "<@bob>: 0/6, gave up\n")
+	assert.True(t, strings.HasSuffix(board, "<@alice> wins! The word was ||party||"))
+	assert.Empty(t, scoreboardComponents(m))
+
+	m = testMatch()
+	finished := time.Now()
+	for _, p := range m.Players {
+		_ = m.Sessions[p].Guess(solution)
+		m.finish(p, finished)
+	}
+	assert.True(t, strings.HasSuffix(m.Scoreboard(), "It's a tie! The word was ||party||"))
+
+	m = testMatch()
+	m.Sessions["alice"].Forfeit()
+	m.Sessions["bob"].Forfeit()
+	assert.True(t, strings.HasSuffix(m.Scoreboard(), "Nobody solved it! The word was ||party||"))
+}
+
+func TestRandomPuzzle(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		n := randomPuzzle(3)
+		assert.GreaterOrEqual(t, n, 1)
+		assert.LessOrEqual(t, n, 3)
+	}
+}
+
+func TestRacePuzzle(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		n, err := racePuzzle(words.DefaultLanguage, words.DefaultLength, config.Default(), 10)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, n, 1)
+		assert.Less(t, n, 10) // never today's puzzle, or one that's still to come
+	}
+
+	cfg := config.Default()
+	cfg.MinPuzzle = 5
+	cfg.MaxPuzzle = 6
+	for i := 0; i < 100; i++ {
+		n, err := racePuzzle(words.DefaultLanguage, words.DefaultLength, cfg, 10)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, n, 5)
+		assert.LessOrEqual(t, n, 6)
+	}
+
+	_, err := racePuzzle(words.DefaultLanguage, words.DefaultLength, config.Default(), 1)
+	assert.ErrorIs(t, err, errNoRacePuzzles)
+	cfg.MinPuzzle, cfg.MaxPuzzle = 20, 30
+	_, err = racePuzzle(words.DefaultLanguage, words.DefaultLength, cfg, 10)
+	assert.ErrorIs(t, err, errNoRacePuzzles)
+}
+
+func testMatch() *Match {
+	m := &Match{
+		ID:       "match",
+		Players:  []string{"alice", "bob"},
+		Sessions: make(map[string]*WordleSession),
+		Finished: make(map[string]time.Time),
+		Joined:   make(map[string]bool),
+	}
+	for _, p := range m.Players {
+		m.Sessions[p] = testSetup()
+		m.Sessions[p].Started = time.Date(2022, time.February, 1, 12, 0, 0, 0, time.UTC)
+		m.Sessions[p].Match = m.ID
+		m.Joined[p] = true
+	}
+	return m
+}
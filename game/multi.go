@@ -0,0 +1,273 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/saxypandabear/wordlego/guess"
+	"github.com/saxypandabear/wordlego/words"
+)
+
+// Discord doesn't allow messages longer than this many characters
+const maxMessageLength = 2000
+
+// the most characters that the boards of a multi-board game can take up in a message,
+// which leaves room for the title, the keyboard and the text of a result message
+const boardsBudget = maxMessageLength - 500
+
+// explains the letters of the boards when they're shown without colors, see formatPlainCell
+const plainLegend = "ABC: right spot, abc: wrong spot, ·: not in the word\n"
+
+// the most boards that are laid out side by side, before starting another row of boards
+const boardsPerRow = 4
+
+// variants are the names of the multi-board games, by their number of boards
+var variants = map[int]string{
+	2: "Dordle",
+	4: "Quordle",
+	8: "Octordle",
+}
+
+// extraGuesses is how many more guesses a multi-board game gets than a single board,
+// by the number of boards, which is 7, 9 and 13 guesses with the usual 6
+var extraGuesses = map[int]int{
+	2: 1,
+	4: 3,
+	8: 7,
+}
+
+// errMultiMode is returned when a multi-board game is started in a mode that doesn't
+// support more than one board.
+var errMultiMode = errors.New("Multi-board games can only be played on your own, with a numbered puzzle.")
+
+// errMultiPuzzle is returned when there aren't enough puzzles before a multi-board game's
+// puzzle to pick its words from, see multiSolutions.
+var errMultiPuzzle = errors.New("There aren't enough earlier puzzles to pick the words of this multi-board game from. Pick a later puzzle.")
+
+// multiSolutions picks a different solution for each of the boards of a multi-board
+// game. The solutions are picked at random, but always the same way for the puzzle
+// number, so that everyone that plays the same multi-board puzzle gets the same words.
+// They're only picked from the puzzles before it, or before today for a later puzzle,
+// so a multi-board game never gives away the word of today's puzzle or of one to come.
+func multiSolutions(language string, length, puzzle, boards, today int) ([]string, error) {
+	count, err := words.PuzzleCount(language, length)
+	if err != nil {
+		return nil, err
+	}
+	if puzzle > today {
+		puzzle = today
+	}
+	if count > puzzle-1 {
+		count = puzzle - 1
+	}
+	if count < boards {
+		return nil, errMultiPuzzle
+	}
+	perm := rand.New(rand.NewSource(int64(puzzle)<<4 | int64(boards))).Perm(count)
+	solutions := make([]string, boards)
+	for i, n := range perm[:boards] {
+		if solutions[i], err = words.GetSolution(language, length, n+1); err != nil {
+			return nil, err
+		}
+	}
+	return solutions, nil
+}
+
+// newMultiSession creates a session for a multi-board game, with a board for each of
+// the solutions, which all share the allowed number of guesses. See guessBoards.
+func newMultiSession(solutions []string, language string, allowedGuesses, puzzleNum int) *WordleSession {
+	ws := NewSession("", allowedGuesses, puzzleNum)
+	ws.Language = language
+	for _, sol := range solutions {
+		board := NewSession(sol, allowedGuesses, puzzleNum)
+		board.Language = language
+		ws.Boards = append(ws.Boards, board)
+	}
+	return ws
+}
+
+// guessBoards applies the guess to every board of a multi-board session that hasn't
+// been solved yet. Solved boards are frozen, so they don't take any more guesses. The
+// session is solved once all of its boards are. In hard mode, the word has to follow
+// the hard mode rules on every board that it's applied to.
+func (ws *WordleSession) guessBoards(word string) error {
+	var active []*WordleSession
+	for i, b := range ws.Boards {
+		if b.IsSolved() {
+			continue
+		}
+		if ws.HardMode {
+			if err := b.checkHardMode(word); err != nil {
+				return fmt.Errorf("board %d: %w", i+1, err)
+			}
+		}
+		active = append(active, b)
+	}
+	for _, b := range active {
+		if err := b.Guess(word); err != nil {
+			return err
+		}
+	}
+	ws.Attempts = append(ws.Attempts, word)
+	ws.updateRuledOut(word)
+	ws.solved = true
+	for _, b := range ws.Boards {
+		ws.solved = ws.solved && b.IsSolved()
+	}
+	return nil
+}
+
+// updateRuledOut marks the letters of the word that aren't in any of the boards' solutions
+// as absent, which is the only state that the letters of a multi-board session can share.
+// A letter isn't in a solved board if the board never found it, or if it was solved before
+// the letter was guessed and its solution doesn't have the letter.
+func (ws *WordleSession) updateRuledOut(word string) {
+	for _, c := range word {
+		absent := true
+		for _, b := range ws.Boards {
+			if b.Letters[c] != guess.Absent && !(b.IsSolved() && !strings.ContainsRune(b.Solution, c)) {
+				absent = false
+				break
+			}
+		}
+		if absent {
+			ws.Letters[c] = guess.Absent
+		}
+	}
+}
+
+// solutions returns the words that have to be guessed in the session, which is one for
+// each board of a multi-board session.
+func (ws *WordleSession) solutions() []string {
+	if len(ws.Boards) == 0 {
+		return []string{ws.Solution}
+	}
+	sols := make([]string, len(ws.Boards))
+	for i, b := range ws.Boards {
+		sols[i] = b.Solution
+	}
+	return sols
+}
+
+// reveal returns a sentence that reveals the session's solutions, behind spoiler tags.
+func (ws *WordleSession) reveal() string {
+	sols := ws.solutions()
+	if len(sols) == 1 {
+		return fmt.Sprintf("The word was ||%s||", sols[0])
+	}
+	for i, sol := range sols {
+		sols[i] = fmt.Sprintf("||%s||", sol)
+	}
+	return "The words were " + strings.Join(sols, ", ")
+}
+
+// formatBoards lays out the boards of a multi-board session side by side, with the
+// letters colored like FormatGuess. When the colored boards don't fit in the message,
+// they fall back to plain text, so that even eight boards fit, see formatPlainCell.
+func (ws *WordleSession) formatBoards() string {
+	if boards := ws.layoutBoards(formatColoredCell, "  ", false); utf8.RuneCountInString(boards) <= boardsBudget {
+		return boards
+	}
+	return plainLegend + ws.layoutBoards(formatPlainCell, " ", true)
+}
+
+// layoutBoards lays out the boards with only as many boards in a row as boardsPerRow,
+// formatting each of their guesses with the cell function. Every row of guesses lines up
+// across the boards, so a board that was solved early is blank after the guess that solved
+// it. Each row starts with the guess itself when the cells don't show all of its letters.
+func (ws *WordleSession) layoutBoards(cell func(*guess.Guess) string, sep string, withAttempts bool) string {
+	var b strings.Builder
+	for start := 0; start < len(ws.Boards); start += boardsPerRow {
+		if start > 0 {
+			b.WriteString("\n")
+		}
+		row := ws.Boards[start:minInt(start+boardsPerRow, len(ws.Boards))]
+		for i, attempt := range ws.Attempts {
+			cells := make([]string, len(row))
+			for j, board := range row {
+				cells[j] = strings.Repeat(" ", ws.length())
+				if i < len(board.Guesses) {
+					cells[j] = cell(board.Guesses[i])
+				}
+			}
+			if withAttempts {
+				b.WriteString(attempt + " | ")
+			}
+			b.WriteString(strings.Join(cells, sep) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// formatColoredCell formats one guess on one board, like FormatGuess, but the color is
+// only changed between letters that are colored differently, to save on characters.
+func formatColoredCell(g *guess.Guess) string {
+	var b strings.Builder
+	current := guess.Unknown - 1
+	for _, l := range g.Letters {
+		if l.Correctness != current {
+			current = l.Correctness
+			b.WriteString(strings.TrimSuffix(l.ColoredText(), string(l.Char)))
+		}
+		b.WriteRune(l.Char)
+	}
+	b.WriteString(guess.ResetText)
+	return b.String()
+}
+
+// formatPlainCell formats one guess on one board without any colors. The letters in the
+// correct position are uppercase, the letters in the wrong position are lowercase, and
+// the letters that aren't in the word are dots.
+func formatPlainCell(g *guess.Guess) string {
+	var b strings.Builder
+	for _, l := range g.Letters {
+		switch l.Correctness {
+		case guess.Correct:
+			b.WriteRune(unicode.ToUpper(l.Char))
+		case guess.Present:
+			b.WriteRune(l.Char)
+		default:
+			b.WriteRune('·')
+		}
+	}
+	return b.String()
+}
+
+// formatScores summarizes the boards of a multi-board session for sharing, without
+// giving away any letters, like the emojis of a single board. Each board shows the
+// number of guesses it was solved in, or an X, laid out like layoutBoards.
+func (ws *WordleSession) formatScores() string {
+	var b strings.Builder
+	for start := 0; start < len(ws.Boards); start += boardsPerRow {
+		row := ws.Boards[start:minInt(start+boardsPerRow, len(ws.Boards))]
+		scores := make([]string, len(row))
+		for j, board := range row {
+			score := "X"
+			if board.IsSolved() {
+				score = fmt.Sprint(len(board.Attempts))
+			}
+			scores[j] = fmt.Sprintf("%2s", score)
+		}
+		b.WriteString(strings.Join(scores, " ") + "\n")
+	}
+	return b.String()
+}
+
+// length returns the number of letters in the session's words.
+func (ws *WordleSession) length() int {
+	if len(ws.Boards) > 0 {
+		return ws.Boards[0].length()
+	}
+	return utf8.RuneCountInString(ws.Solution)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
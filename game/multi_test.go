@@ -0,0 +1,189 @@
+package game
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/saxypandabear/wordlego/config"
+	"github.com/saxypandabear/wordlego/guess"
+	"github.com/saxypandabear/wordlego/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiSolutions(t *testing.T) {
+	sols, err := multiSolutions(words.DefaultLanguage, words.DefaultLength, 42, 8, 1000)
+	assert.NoError(t, err)
+	assert.Len(t, sols, 8)
+	seen := make(map[string]bool)
+	for _, sol := range sols {
+		assert.False(t, seen[sol])
+		seen[sol] = true
+	}
+
+	// the same puzzle always has the same words, whichever day it's played on
+	again, err := multiSolutions(words.DefaultLanguage, words.DefaultLength, 42, 8, 2000)
+	assert.NoError(t, err)
+	assert.Equal(t, sols, again)
+	other, err := multiSolutions(words.DefaultLanguage, words.DefaultLength, 43, 8, 1000)
+	assert.NoError(t, err)
+	assert.NotEqual(t, sols, other)
+
+	_, err = multiSolutions(words.DefaultLanguage, 3, 42, 2, 1000)
+	assert.Error(t, err)
+	_, err = multiSolutions(words.DefaultLanguage, words.DefaultLength, 5, 8, 1000)
+	assert.ErrorIs(t, err, errMultiPuzzle)
+}
+
+func TestMultiSolutionsAreEarlierPuzzles(t *testing.T) {
+	earlier := func(n int) map[string]bool {
+		m := make(map[string]bool)
+		for _, sol := range words.Solutions[:n-1] {
+			m[sol] = true
+		}
+		return m
+	}
+
+	sols, err := multiSolutions(words.DefaultLanguage, words.DefaultLength, 20, 8, 1000)
+	assert.NoError(t, err)
+	before := earlier(20)
+	for _, sol := range sols {
+		assert.True(t, before[sol], "%s isn't the word of a puzzle before 20", sol)
+	}
+
+	// a later puzzle only gets the words from before today
+	sols, err = multiSolutions(words.DefaultLanguage, words.DefaultLength, 1000, 8, 10)
+	assert.NoError(t, err)
+	before = earlier(10)
+	for _, sol := range sols {
+		assert.True(t, before[sol], "%s isn't the word of a puzzle before today", sol)
+	}
+}
+
+func TestMultiSession(t *testing.T) {
+	ws := newMultiSession([]string{"party", "beams"}, words.DefaultLanguage, 7, puzzleNum)
+	assert.True(t, strings.HasPrefix(ws.PrintGame(true), "```ansi\nDordle 1: 0/7\n"))
+	assert.EqualError(t, ws.Guess("part"), "'part' has 4 letters, but this puzzle's word has 5")
+
+	assert.NoError(t, ws.Guess("pants"))
+	assert.Len(t, ws.Boards[0].Attempts, 1)
+	assert.Len(t, ws.Boards[1].Attempts, 1)
+	// only the letters that are in neither word are ruled out
+	assert.Equal(t, guess.Absent, ws.Letters['n'])
+	assert.Equal(t, guess.Unknown, ws.Letters['s'])
+	assert.Equal(t, guess.Unknown, ws.Letters['p'])
+
+	// a solved board is frozen
+	assert.NoError(t, ws.Guess("party"))
+	assert.True(t, ws.Boards[0].IsSolved())
+	assert.False(t, ws.IsSolved())
+	assert.NoError(t, ws.Guess("dirty"))
+	assert.Len(t, ws.Boards[0].Attempts, 2)
+	assert.Len(t, ws.Boards[1].Attempts, 3)
+	// d was never guessed on the solved board, but it isn't in its word either
+	assert.Equal(t, guess.Absent, ws.Letters['d'])
+	assert.Equal(t, guess.Unknown, ws.Letters['y'])
+
+	assert.NoError(t, ws.Guess("beams"))
+	assert.True(t, ws.IsSolved())
+	assert.False(t, ws.CanPlay())
+	assert.Equal(t, " 2  4\n", ws.FormatEmojis(false))
+	assert.Equal(t, "The words were ||party||, ||beams||", ws.reveal())
+	assert.Equal(t, "party,beams", ws.Result("player", ws.Started).Solution)
+	assert.False(t, ws.Result("player", ws.Started).Daily)
+}
+
+func TestMultiSessionHardMode(t *testing.T) {
+	ws := newMultiSession([]string{"party", "beams"}, words.DefaultLanguage, 7, puzzleNum)
+	ws.HardMode = true
+	assert.NoError(t, ws.Guess("pants"))
+	assert.EqualError(t, ws.Guess("beach"), "board 1: 1st letter must be P")
+	assert.Len(t, ws.Attempts, 1)
+	assert.Len(t, ws.Boards[1].Attempts, 1)
+}
+
+func TestMultiSessionOutOfGuesses(t *testing.T) {
+	ws := newMultiSession([]string{"party", "beams"}, words.DefaultLanguage, 2, puzzleNum)
+	assert.NoError(t, ws.Guess("party"))
+	assert.NoError(t, ws.Guess("pants"))
+	assert.False(t, ws.CanPlay())
+	assert.False(t, ws.IsSolved())
+	assert.Equal(t, " 1  X\n", ws.FormatEmojis(false))
+}
+
+func TestFormatBoards(t *testing.T) {
+	ws := newMultiSession([]string{"party", "beams"}, words.DefaultLanguage, 7, puzzleNum)
+	_ = ws.Guess("party")
+	_ = ws.Guess("pants")
+	lines := strings.Split(ws.FormatGuesses(false), "\n")
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], guess.GreenText+"party"+guess.ResetText+"  "))
+	// the solved board is blank after it was solved
+	assert.True(t, strings.HasPrefix(lines[1], "       "+guess.AbsentText+"p"))
+
+	// eight boards don't fit with colors, so they're shown as plain text
+	sols := []string{"party", "beams", "crane", "moist", "light", "dough", "quick", "fjord"}
+	ws = newMultiSession(sols, words.DefaultLanguage, 13, puzzleNum)
+	for _, g := range []string{"abcde", "fghij", "klmno", "pqrst", "uvwxy", "zabcd", "efghi", "jklmn", "opqrs", "tuvwx", "yzabc", "defgh", "ijklm"} {
+		assert.NoError(t, ws.Guess(g))
+	}
+	board := ws.PrintGame(false)
+	assert.LessOrEqual(t, utf8.RuneCountInString(board), maxMessageLength-100)
+	assert.Contains(t, board, plainLegend)
+	assert.Contains(t, board, "\nabcde | ")
+	assert.Contains(t, ws.formatBoards(), "ijklm | ")
+
+	// even the longest Octordle that can be started fits in a message
+	sols, err := multiSolutions(words.DefaultLanguage, words.MaxLength, 100, 8, 1000)
+	assert.NoError(t, err)
+	ws = newMultiSession(sols, words.DefaultLanguage, config.MaxMaxGuesses+extraGuesses[8], 100)
+	for n := 0; ws.CanPlay(); n++ {
+		assert.NoError(t, ws.Guess(words.EightLetterAllowedWords[n]))
+	}
+	content, _ := boardMessage(ws)
+	assert.LessOrEqual(t, utf8.RuneCountInString(content), maxMessageLength)
+}
+
+func TestFormatPlainCell(t *testing.T) {
+	g, err := guess.ConvertToGuess("pants", "party")
+	assert.NoError(t, err)
+	assert.Equal(t, "PA·T·", formatPlainCell(g))
+	g, err = guess.ConvertToGuess("tramp", "party")
+	assert.NoError(t, err)
+	assert.Equal(t, "tra·p", formatPlainCell(g))
+}
+
+func TestMultiSessionJSON(t *testing.T) {
+	ws := newMultiSession([]string{"party", "beams"}, words.DefaultLanguage, 7, puzzleNum)
+	_ = ws.Guess("pants")
+	_ = ws.Guess("party")
+	data, err := json.Marshal(ws)
+	assert.NoError(t, err)
+
+	var restored WordleSession
+	assert.NoError(t, json.Unmarshal(data, &restored))
+	assert.Len(t, restored.Boards, 2)
+	assert.True(t, restored.Boards[0].IsSolved())
+	assert.False(t, restored.IsSolved())
+	assert.Equal(t, ws.Letters, restored.Letters)
+	assert.Equal(t, ws.PrintGame(false), restored.PrintGame(false))
+}
+
+func TestStartMultiSession(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	args := &CommandArgs{PuzzleNum: 100, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage, Boards: 4}
+	sess, err := startSession("player", "player", args)
+	assert.NoError(t, err)
+	assert.Len(t, sess.Boards, 4)
+	assert.Equal(t, 9, sess.MaxAllowedGuesses)
+	assert.Equal(t, "Quordle 100", sess.title())
+
+	args.Boards = 3
+	_, err = startSession("other", "other", args)
+	assert.Error(t, err)
+
+	args.Boards, args.PuzzleNum = 4, 1
+	_, err = startSession("other", "other", args)
+	assert.ErrorIs(t, err, errMultiPuzzle)
+}
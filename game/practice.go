@@ -0,0 +1,116 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/saxypandabear/wordlego/config"
+	"github.com/saxypandabear/wordlego/words"
+)
+
+// PracticeAgainButtonID is the custom ID of the button that starts another practice
+// game once one is finished. The settings of the finished game are added to the ID,
+// separated by colons, so that the next game is played the same way. See practiceAgainID
+const PracticeAgainButtonID = "wordle-practice-again"
+
+// errors that are shown directly to the player
+var (
+	errPracticeCode      = errors.New("Practice games pick their own puzzle, so they can't be played with a code.")
+	errNoPracticePuzzles = errors.New("You've played every puzzle that there is to practice with! Try another length or language.")
+)
+
+// practicePuzzle picks a random puzzle for the player to practice with, out of the
+// puzzles for the language and length whose words they haven't played before. Only
+// puzzles from before today's can be picked, and never today's word, so that practice
+// doesn't spoil a daily puzzle. The guild's range of puzzles is respected too.
+// This returns errNoPracticePuzzles if there aren't any puzzles left for the player.
+func practicePuzzle(player, language string, length int, cfg config.GuildConfig, today int) (int, error) {
+	count, err := words.PuzzleCount(language, length)
+	if err != nil {
+		return 0, err
+	}
+	played := make(map[string]bool)
+	if sol, err := words.GetSolution(language, length, today); err == nil {
+		played[sol] = true
+	}
+	for _, r := range results.Results(player) {
+		if r.Language == language {
+			played[r.Solution] = true
+		}
+	}
+
+	var unplayed []int
+	for n := 1; n <= count && n < today; n++ {
+		if !cfg.AllowsPuzzle(n, today) {
+			continue
+		}
+		sol, err := words.GetSolution(language, length, n)
+		if err != nil {
+			return 0, err
+		}
+		if !played[sol] {
+			unplayed = append(unplayed, n)
+		}
+	}
+	if len(unplayed) == 0 {
+		return 0, errNoPracticePuzzles
+	}
+	return unplayed[randomPuzzle(len(unplayed))-1], nil
+}
+
+// practiceAgainID returns the custom ID of the button for playing another practice
+// game like the session, with its language, length, max guesses, hard mode and keyboard.
+func practiceAgainID(sess *WordleSession) string {
+	return strings.Join([]string{
+		PracticeAgainButtonID,
+		sess.Language,
+		strconv.Itoa(sess.length()),
+		strconv.Itoa(sess.MaxAllowedGuesses),
+		strconv.FormatBool(sess.HardMode),
+		sess.Keyboard,
+	}, ":")
+}
+
+// parsePracticeAgainID reads the settings for the next practice game back out of the
+// custom ID of the button, see practiceAgainID.
+func parsePracticeAgainID(id string) (*CommandArgs, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 6 || parts[0] != PracticeAgainButtonID {
+		return nil, fmt.Errorf("'%s' isn't the ID of a practice button", id)
+	}
+	length, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	maxGuesses, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return nil, err
+	}
+	hardMode, err := strconv.ParseBool(parts[4])
+	if err != nil {
+		return nil, err
+	}
+	return &CommandArgs{
+		GameAction: Start,
+		Mode:       Practice,
+		Language:   parts[1],
+		Length:     length,
+		MaxGuesses: maxGuesses,
+		HardMode:   hardMode,
+		Keyboard:   parts[5],
+	}, nil
+}
+
+// PracticeAgainButton is the hook for the Again button on the result of a practice
+// game. It starts another practice game for whoever clicked it, with the same settings.
+func PracticeAgainButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	args, err := parsePracticeAgainID(i.MessageComponentData().CustomID)
+	if err != nil {
+		respondEphemeral(s, i, "This button doesn't work anymore. Start a new game with /wordle start mode:practice")
+		return
+	}
+	start(s, i, args)
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/saxypandabear/wordlego/config"
+	"github.com/saxypandabear/wordlego/stats"
+	"github.com/saxypandabear/wordlego/words"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPracticePuzzle(t *testing.T) {
+	UseStatsStore(stats.NewMemoryStore())
+	cfg := config.Default()
+	const today = 10
+
+	// only the puzzles from before today can be picked
+	for i := 0; i < 50; i++ {
+		n, err := practicePuzzle("player", words.DefaultLanguage, words.DefaultLength, cfg, today)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, n, 1)
+		assert.Less(t, n, today)
+	}
+
+	// the guild's range is respected, and puzzles the player has played are left out
+	cfg.MinPuzzle, cfg.MaxPuzzle = 2, 3
+	assert.NoError(t, results.Record(stats.Result{Player: "player", Language: words.DefaultLanguage, Solution: words.Solutions[1]}))
+	for i := 0; i < 10; i++ {
+		n, err := practicePuzzle("player", words.DefaultLanguage, words.DefaultLength, cfg, today)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, n)
+	}
+	assert.NoError(t, results.Record(stats.Result{Player: "player", Language: words.DefaultLanguage, Solution: words.Solutions[2], Practice: true}))
+	_, err := practicePuzzle("player", words.DefaultLanguage, words.DefaultLength, cfg, today)
+	assert.ErrorIs(t, err, errNoPracticePuzzles)
+
+	// other players have their own history
+	n, err := practicePuzzle("other", words.DefaultLanguage, words.DefaultLength, cfg, today)
+	assert.NoError(t, err)
+	assert.Contains(t, []int{2, 3}, n)
+
+	_, err = practicePuzzle("player", words.DefaultLanguage, 3, cfg, today)
+	assert.Error(t, err)
+}
+
+func TestPracticeSession(t *testing.T) {
+	UseSessionStore(NewMemoryStore())
+	UseStatsStore(stats.NewMemoryStore())
+	args := &CommandArgs{PuzzleNum: 1, MaxGuesses: allowedGuesses, Length: words.DefaultLength, Language: words.DefaultLanguage, Mode: Practice}
+
+	sess, err := startSession("player", "player", args)
+	assert.NoError(t, err)
+	assert.True(t, sess.Practice)
+
+	sess, err = guessSession("player", "player", sess.Solution)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sess.PrintGame(true), "```ansi\nWordle 1 (practice): 1/6\n"))
+	recorded := results.Results("player")
+	assert.Len(t, recorded, 1)
+	assert.True(t, recorded[0].Practice)
+	assert.Equal(t, 0, stats.Compute(recorded).Played)
+	buttons := resultComponents(sess, 0)[0].(discordgo.ActionsRow).Components
+	assert.Len(t, buttons, 2)
+	assert.Equal(t, practiceAgainID(sess), buttons[0].(discordgo.Button).CustomID)
+
+	sess.Practice = false
+	buttons = resultComponents(sess, 0)[0].(discordgo.ActionsRow).Components
+	assert.Len(t, buttons, 1)
+	assert.True(t, strings.HasPrefix(buttons[0].(discordgo.Button).CustomID, AnalyzeButtonID+":"))
+}
+
+func TestPracticeAgainID(t *testing.T) {
+	sess := NewSession("küche", 8, 1)
+	sess.Language = words.German.Code
+	sess.HardMode = true
+	sess.Keyboard = GermanQWERTZ.Name
+
+	id := practiceAgainID(sess)
+	assert.True(t, strings.HasPrefix(id, PracticeAgainButtonID+":"))
+	args, err := parsePracticeAgainID(id)
+	assert.NoError(t, err)
+	assert.Equal(t, &CommandArgs{
+		GameAction: Start,
+		Mode:       Practice,
+		Language:   words.German.Code,
+		Length:     5,
+		MaxGuesses: 8,
+		HardMode:   true,
+		Keyboard:   GermanQWERTZ.Name,
+	}, args)
+
+	_, err = parsePracticeAgainID(PracticeAgainButtonID)
+	assert.Error(t, err)
+	_, err = parsePracticeAgainID(PracticeAgainButtonID + ":en:five:6:false:qwerty")
+	assert.Error(t, err)
+}
@@ -0,0 +1,150 @@
+package game
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/saxypandabear/wordlego/internal/jsonfile"
+)
+
+// SessionStore keeps track of the active game sessions, keyed by the ID of
+// the player that owns the session. Implementations must be safe to use from
+// multiple goroutines.
+// Note that sessions are mutated in place when a player guesses, so callers
+// need to Put the session back into the store after each change in order for
+// a durable store to persist it.
+type SessionStore interface {
+	// Get returns the session for the given player, and whether or not it exists
+	Get(id string) (*WordleSession, bool)
+	// Put creates or replaces the session for the given player
+	Put(id string, ws *WordleSession) error
+	// Delete removes the session for the given player. Deleting a session
+	// that does not exist is not an error.
+	Delete(id string) error
+	// List returns all of the active sessions, keyed by player ID
+	List() map[string]*WordleSession
+}
+
+// MemoryStore is a SessionStore that only keeps sessions in memory. All of the
+// sessions are lost when the bot restarts.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	sessions map[string]*WordleSession
+}
+
+// NewMemoryStore creates an empty in-memory session store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sessions: make(map[string]*WordleSession),
+	}
+}
+
+func (m *MemoryStore) Get(id string) (*WordleSession, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ws, ok := m.sessions[id]
+	return ws, ok
+}
+
+func (m *MemoryStore) Put(id string, ws *WordleSession) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[id] = ws
+	return nil
+}
+
+func (m *MemoryStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *MemoryStore) List() map[string]*WordleSession {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	list := make(map[string]*WordleSession, len(m.sessions))
+	for id, ws := range m.sessions {
+		list[id] = ws
+	}
+	return list
+}
+
+// FileStore is a SessionStore that writes all of the sessions to a JSON file
+// on disk every time a session changes, so that active games survive the bot
+// restarting. The sessions are also kept in memory, so reads never touch the disk.
+// Each session is serialized when it is Put, rather than when the file is written,
+// so that writing the file never reads a session that another player is changing.
+type FileStore struct {
+	mu       sync.RWMutex
+	path     string
+	sessions map[string]*WordleSession
+	encoded  map[string]json.RawMessage // the last saved state of each session
+}
+
+// NewFileStore creates a session store that is backed by the JSON file at the
+// given path. If the file already exists, the sessions in it are loaded into
+// the store. If it does not exist, it is created on the first write.
+func NewFileStore(path string) (*FileStore, error) {
+	fs := FileStore{
+		path:     path,
+		sessions: make(map[string]*WordleSession),
+		encoded:  make(map[string]json.RawMessage),
+	}
+	if err := jsonfile.Load(path, &fs.encoded); err != nil {
+		return nil, err
+	}
+	for id, encoded := range fs.encoded {
+		var ws WordleSession
+		if err := json.Unmarshal(encoded, &ws); err != nil {
+			return nil, err
+		}
+		fs.sessions[id] = &ws
+	}
+	return &fs, nil
+}
+
+func (f *FileStore) Get(id string) (*WordleSession, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	ws, ok := f.sessions[id]
+	return ws, ok
+}
+
+func (f *FileStore) Put(id string, ws *WordleSession) error {
+	encoded, err := json.Marshal(ws)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sessions[id] = ws
+	f.encoded[id] = encoded
+	return f.save()
+}
+
+func (f *FileStore) Delete(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.sessions[id]; !ok {
+		return nil
+	}
+	delete(f.sessions, id)
+	delete(f.encoded, id)
+	return f.save()
+}
+
+func (f *FileStore) List() map[string]*WordleSession {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	list := make(map[string]*WordleSession, len(f.sessions))
+	for id, ws := range f.sessions {
+		list[id] = ws
+	}
+	return list
+}
+
+// save writes all of the sessions to the file. The caller must hold the write lock.
+func (f *FileStore) save() error {
+	return jsonfile.Save(f.path, f.encoded)
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/saxypandabear/wordlego/guess"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+	testStore(t, store)
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "sessions.json"))
+	assert.NoError(t, err)
+	testStore(t, store)
+}
+
+func TestFileStoreSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	store, err := NewFileStore(path)
+	assert.NoError(t, err)
+
+	solved := testSetup()
+	_ = solved.Guess("pants")
+	_ = solved.Guess(solution)
+	inProgress := testSetup()
+	_ = inProgress.Guess("hello")
+	inProgress.Board = &Board{
+		AppID:   "app",
+		Token:   "token",
+		Created: time.Date(2022, time.February, 1, 12, 0, 0, 0, time.UTC),
+	}
+	assert.NoError(t, store.Put("solved", solved))
+	assert.NoError(t, store.Put("in-progress", inProgress))
+
+	restarted, err := NewFileStore(path)
+	assert.NoError(t, err)
+	assert.Len(t, restarted.List(), 2)
+
+	ws, ok := restarted.Get("solved")
+	assert.True(t, ok)
+	assert.True(t, ws.IsSolved())
+	assert.False(t, ws.CanPlay())
+	assert.Equal(t, solved, ws)
+
+	ws, ok = restarted.Get("in-progress")
+	assert.True(t, ok)
+	assert.False(t, ws.IsSolved())
+	assert.True(t, ws.CanPlay())
+	assert.Equal(t, inProgress, ws)
+	// the restored session should keep playing like the original one
+	assert.EqualError(t, ws.Guess("hello"), "hello has already been guessed in this player's session")
+	assert.NoError(t, ws.Guess(solution))
+	assert.True(t, ws.IsSolved())
+}
+
+// sessions that were saved before the used letters were keyed by letter, and before
+// games could be played in other languages, should still load
+func TestFileStoreOlderSessions(t *testing.T) {
+	ws := testSetup()
+	_ = ws.Guess("pants")
+	data, err := json.Marshal(ws)
+	assert.NoError(t, err)
+	var old map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &old))
+	delete(old, "Language")
+	old["Letters"] = make([]int, 26)
+	data, err = json.Marshal(map[string]interface{}{"player": old})
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	assert.NoError(t, ioutil.WriteFile(path, data, 0644))
+	store, err := NewFileStore(path)
+	assert.NoError(t, err)
+	restored, ok := store.Get("player")
+	assert.True(t, ok)
+	assert.Equal(t, ws, restored)
+	assert.Equal(t, guess.Correct, restored.Letters['p'])
+}
+
+func TestFileStoreInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	store, err := NewFileStore(path)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Put("player", testSetup()))
+
+	// a directory can't be read as a file
+	_, err = NewFileStore(filepath.Dir(path))
+	assert.Error(t, err)
+}
+
+func testStore(t *testing.T, store SessionStore) {
+	_, ok := store.Get("player")
+	assert.False(t, ok)
+	assert.Empty(t, store.List())
+
+	ws := testSetup()
+	assert.NoError(t, store.Put("player", ws))
+	actual, ok := store.Get("player")
+	assert.True(t, ok)
+	assert.Same(t, ws, actual)
+	assert.Len(t, store.List(), 1)
+
+	// modifying the listed sessions shouldn't affect the store
+	delete(store.List(), "player")
+	assert.Len(t, store.List(), 1)
+
+	assert.NoError(t, store.Delete("player"))
+	_, ok = store.Get("player")
+	assert.False(t, ok)
+	assert.Empty(t, store.List())
+	// deleting again is fine
+	assert.NoError(t, store.Delete("player"))
+}
@@ -3,7 +3,7 @@ module github.com/saxypandabear/wordlego
 go 1.17
 
 require (
-	github.com/bwmarrin/discordgo v0.23.3-0.20220202194601-aba5dc811da8
+	github.com/bwmarrin/discordgo v0.27.1
 	github.com/stretchr/testify v1.7.0
 )
 
@@ -1,6 +1,9 @@
 package guess
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 const (
 	GreenSquare  = "🟩"
@@ -8,17 +11,30 @@ const (
 	BlackSquare  = "⬛"
 	GreenText    = "[0m[1;32m"
 	YellowText   = "[0m[1:33m"
+	AbsentText   = "[0m[1;30m"
 	DefaultText  = "[0m[1;37m"
 	ResetText    = "[0m"
 )
 
+// Correctness is the state of a letter, either within a single guess, or across
+// all of the guesses in a game. The states are ordered from the least to the most
+// information known about the letter, so a better state always compares greater.
+type Correctness int
+
+const (
+	Unknown Correctness = iota // the letter has not been guessed yet
+	Absent                     // the letter is not in the solution
+	Present                    // the letter is in the solution, but not in this position
+	Correct                    // the letter is in the solution, in this position
+)
+
 type Guess struct {
 	Letters []*Letter
 }
 
 type Letter struct {
 	Char        rune
-	Correctness int
+	Correctness Correctness
 }
 
 // ColoredText returns an ANSI formatted string that highlights the rune
@@ -26,11 +42,14 @@ type Letter struct {
 // the context of the guess.
 func (l *Letter) ColoredText() string {
 	var col string
-	if l.Correctness == 2 {
+	switch l.Correctness {
+	case Correct:
 		col = GreenText
-	} else if l.Correctness == 1 {
+	case Present:
 		col = YellowText
-	} else {
+	case Absent:
+		col = AbsentText
+	default:
 		col = DefaultText
 	}
 	return col + string(l.Char)
@@ -40,11 +59,11 @@ func (l *Letter) ColoredText() string {
 // within the context of the guess.
 func (l *Letter) Emoji() string {
 	switch l.Correctness {
-	case 0:
+	case Absent:
 		return BlackSquare
-	case 1:
+	case Present:
 		return YellowSquare
-	case 2:
+	case Correct:
 		return GreenSquare
 	default:
 		return BlackSquare
@@ -54,33 +73,53 @@ func (l *Letter) Emoji() string {
 // ConvertToGuess takes a word string, and the reference solution string, and
 // returns a Guess struct that represents the overall correctness/accuracy of
 // the guess when compared to the solution.
-func ConvertToGuess(word, solution string) *Guess {
-	letters := make([]*Letter, len(solution))
-	for i, c := range word {
-		idx := strings.IndexRune(solution, c)
-		var correctness int
-		if i == idx {
-			correctness = 2
-		} else if idx >= 0 { // TODO: account for duplicate letters?
-			correctness = 1
+// This follows the Wordle rules for duplicate letters, which takes two passes:
+// 1. Mark every letter that is in the correct position, and count up the
+// letters in the solution that were not matched.
+// 2. For the remaining letters, mark the letter as present only while there
+// are still unmatched copies of that letter left in the solution.
+// This means that a letter that only appears once in the solution is only ever
+// highlighted once in the guess, with the correct position taking priority.
+// This returns an error if the word isn't the same length as the solution. The
+// words are compared letter by letter, so they can have letters outside of a-z.
+func ConvertToGuess(word, solution string) (*Guess, error) {
+	runes, solutionRunes := []rune(word), []rune(solution)
+	if len(runes) != len(solutionRunes) {
+		return nil, fmt.Errorf("'%s' has %d letters, but the solution has %d", word, len(runes), len(solutionRunes))
+	}
+	letters := make([]*Letter, len(solutionRunes))
+	remaining := make(map[rune]int)
+	for i, c := range runes {
+		correctness := Absent
+		if solutionRunes[i] == c {
+			correctness = Correct
 		} else {
-			correctness = 0 // TODO: Update this to use -1, and add a 4th color to indicate incorrectness
+			remaining[solutionRunes[i]]++
 		}
 		letters[i] = &Letter{
 			Char:        c,
 			Correctness: correctness,
 		}
 	}
+	for _, l := range letters {
+		if l.Correctness == Correct {
+			continue
+		}
+		if remaining[l.Char] > 0 {
+			l.Correctness = Present
+			remaining[l.Char]--
+		}
+	}
 	return &Guess{
 		Letters: letters,
-	}
+	}, nil
 }
 
 // FormatGuess takes a word, and returns an ANSI formatted string using the
 // correctness level of each rune.
-// 0 indicates that the rune is completely invalid ⬛
-// 1 indicates that the rune exists in the solution, but is not in the correct position 🟨
-// 2 indicates that the rune exists and is the correct position 🟩
+// Absent indicates that the rune is completely invalid ⬛
+// Present indicates that the rune exists in the solution, but is not in the correct position 🟨
+// Correct indicates that the rune exists and is the correct position 🟩
 // This function returns the runes that are highlighted in their respective colors.
 func FormatGuess(guess *Guess) string {
 	var b strings.Builder
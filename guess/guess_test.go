@@ -14,7 +14,6 @@ type testtable struct {
 	ansi   string
 }
 
-// TODO: Add a test that accounts for duplicate letters
 var (
 	solution  = "parts"
 	testCases = []testtable{
@@ -24,23 +23,23 @@ var (
 				Letters: []*Letter{
 					{
 						Char:        'p',
-						Correctness: 2,
+						Correctness: Correct,
 					},
 					{
 						Char:        'a',
-						Correctness: 2,
+						Correctness: Correct,
 					},
 					{
 						Char:        'r',
-						Correctness: 2,
+						Correctness: Correct,
 					},
 					{
 						Char:        't',
-						Correctness: 2,
+						Correctness: Correct,
 					},
 					{
 						Char:        's',
-						Correctness: 2,
+						Correctness: Correct,
 					},
 				},
 			},
@@ -53,28 +52,28 @@ var (
 				Letters: []*Letter{
 					{
 						Char:        's',
-						Correctness: 1,
+						Correctness: Present,
 					},
 					{
 						Char:        'n',
-						Correctness: 0,
+						Correctness: Absent,
 					},
 					{
 						Char:        'a',
-						Correctness: 1,
+						Correctness: Present,
 					},
 					{
 						Char:        'i',
-						Correctness: 0,
+						Correctness: Absent,
 					},
 					{
 						Char:        'l',
-						Correctness: 0,
+						Correctness: Absent,
 					},
 				},
 			},
 			emojis: YellowSquare + BlackSquare + YellowSquare + BlackSquare + BlackSquare,
-			ansi:   fmt.Sprintf("%vs%vn%va%vi%vl%v", YellowText, DefaultText, YellowText, DefaultText, DefaultText, ResetText),
+			ansi:   fmt.Sprintf("%vs%vn%va%vi%vl%v", YellowText, AbsentText, YellowText, AbsentText, AbsentText, ResetText),
 		},
 		{
 			input: "pants",
@@ -82,28 +81,28 @@ var (
 				Letters: []*Letter{
 					{
 						Char:        'p',
-						Correctness: 2,
+						Correctness: Correct,
 					},
 					{
 						Char:        'a',
-						Correctness: 2,
+						Correctness: Correct,
 					},
 					{
 						Char:        'n',
-						Correctness: 0,
+						Correctness: Absent,
 					},
 					{
 						Char:        't',
-						Correctness: 2,
+						Correctness: Correct,
 					},
 					{
 						Char:        's',
-						Correctness: 2,
+						Correctness: Correct,
 					},
 				},
 			},
 			emojis: GreenSquare + GreenSquare + BlackSquare + GreenSquare + GreenSquare,
-			ansi:   fmt.Sprintf("%vp%va%vn%vt%vs%v", GreenText, GreenText, DefaultText, GreenText, GreenText, ResetText),
+			ansi:   fmt.Sprintf("%vp%va%vn%vt%vs%v", GreenText, GreenText, AbsentText, GreenText, GreenText, ResetText),
 		},
 	}
 )
@@ -111,14 +110,17 @@ var (
 func TestColoredText(t *testing.T) {
 	letter := Letter{
 		Char:        'b',
-		Correctness: 2,
+		Correctness: Correct,
 	}
 	assert.Equal(t, GreenText+"b", letter.ColoredText())
 
-	letter.Correctness = 1
+	letter.Correctness = Present
 	assert.Equal(t, YellowText+"b", letter.ColoredText())
 
-	letter.Correctness = 0
+	letter.Correctness = Absent
+	assert.Equal(t, AbsentText+"b", letter.ColoredText())
+
+	letter.Correctness = Unknown
 	assert.Equal(t, DefaultText+"b", letter.ColoredText())
 
 	letter.Correctness = -10032431 // random unused number
@@ -128,14 +130,17 @@ func TestColoredText(t *testing.T) {
 func TestEmoji(t *testing.T) {
 	letter := Letter{
 		Char:        'b',
-		Correctness: 2,
+		Correctness: Correct,
 	}
 	assert.Equal(t, GreenSquare, letter.Emoji())
 
-	letter.Correctness = 1
+	letter.Correctness = Present
 	assert.Equal(t, YellowSquare, letter.Emoji())
 
-	letter.Correctness = 0
+	letter.Correctness = Absent
+	assert.Equal(t, BlackSquare, letter.Emoji())
+
+	letter.Correctness = Unknown
 	assert.Equal(t, BlackSquare, letter.Emoji())
 
 	letter.Correctness = 57381234
@@ -145,12 +150,115 @@ func TestEmoji(t *testing.T) {
 func TestConvertToGuess(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.input, func(t *testing.T) {
-			actual := ConvertToGuess(test.input, solution)
+			actual, err := ConvertToGuess(test.input, solution)
+			assert.NoError(t, err)
 			assert.Equal(t, test.guess, actual)
 		})
 	}
 }
 
+func TestConvertToGuessNonASCII(t *testing.T) {
+	actual, err := ConvertToGuess("señor", "señal")
+	assert.NoError(t, err)
+	assert.Len(t, actual.Letters, 5)
+	expected := []Letter{{'s', Correct}, {'e', Correct}, {'ñ', Correct}, {'o', Absent}, {'r', Absent}}
+	for i, l := range actual.Letters {
+		assert.Equal(t, expected[i], *l)
+	}
+
+	actual, err = ConvertToGuess("küche", "glück")
+	assert.NoError(t, err)
+	assert.Equal(t, []Correctness{Present, Present, Present, Absent, Absent},
+		[]Correctness{actual.Letters[0].Correctness, actual.Letters[1].Correctness, actual.Letters[2].Correctness, actual.Letters[3].Correctness, actual.Letters[4].Correctness})
+	_, err = ConvertToGuess("brücke", "glück")
+	assert.EqualError(t, err, "'brücke' has 6 letters, but the solution has 5")
+}
+
+func TestConvertToGuessWrongLength(t *testing.T) {
+	_, err := ConvertToGuess("pant", solution)
+	assert.EqualError(t, err, "'pant' has 4 letters, but the solution has 5")
+	_, err = ConvertToGuess("panted", solution)
+	assert.EqualError(t, err, "'panted' has 6 letters, but the solution has 5")
+
+	actual, err := ConvertToGuess("banter", "better")
+	assert.NoError(t, err)
+	assert.Len(t, actual.Letters, 6)
+	assert.Equal(t, Correct, actual.Letters[0].Correctness)
+	assert.Equal(t, Absent, actual.Letters[1].Correctness)
+}
+
+// duplicate letter cases, following the rules from the illustration linked in the README.
+// each case has its own solution, and the expected correctness of each letter in the guess.
+var duplicateLetterCases = []struct {
+	name        string
+	input       string
+	solution    string
+	correctness []Correctness
+}{
+	{
+		name:        "one e in solution, two in guess",
+		input:       "speed",
+		solution:    "abide",
+		correctness: []Correctness{Absent, Absent, Present, Absent, Present},
+	},
+	{
+		name:        "correct position takes priority over an earlier duplicate",
+		input:       "geese",
+		solution:    "those",
+		correctness: []Correctness{Absent, Absent, Absent, Correct, Correct},
+	},
+	{
+		name:        "two b's in solution, three in guess",
+		input:       "bobby",
+		solution:    "abbey",
+		correctness: []Correctness{Present, Absent, Correct, Absent, Correct},
+	},
+	{
+		name:        "two b's in solution, both misplaced",
+		input:       "kebab",
+		solution:    "abbey",
+		correctness: []Correctness{Absent, Present, Correct, Present, Present},
+	},
+	{
+		name:        "two b's in solution, one correct and one misplaced",
+		input:       "babes",
+		solution:    "abbey",
+		correctness: []Correctness{Present, Present, Correct, Correct, Absent},
+	},
+	{
+		name:        "duplicate in solution, single in guess",
+		input:       "crate",
+		solution:    "sissy",
+		correctness: []Correctness{Absent, Absent, Absent, Absent, Absent},
+	},
+	{
+		name:        "single in guess matches one of the duplicates",
+		input:       "siren",
+		solution:    "sissy",
+		correctness: []Correctness{Correct, Correct, Absent, Absent, Absent},
+	},
+	{
+		name:        "three of a letter in guess, two in solution",
+		input:       "sassy",
+		solution:    "sissy",
+		correctness: []Correctness{Correct, Absent, Correct, Correct, Correct},
+	},
+}
+
+func TestConvertToGuessDuplicateLetters(t *testing.T) {
+	for _, test := range duplicateLetterCases {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := ConvertToGuess(test.input, test.solution)
+			assert.NoError(t, err)
+			assert.Len(t, actual.Letters, len(test.correctness))
+			for i, l := range actual.Letters {
+				assert.Equal(t, rune(test.input[i]), l.Char)
+				assert.Equal(t, test.correctness[i], l.Correctness, "letter %d of %s", i, test.input)
+			}
+		})
+	}
+}
+
 func TestFormatGuessToEmojis(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.input, func(t *testing.T) {
@@ -182,23 +290,23 @@ func BenchmarkConvertGuessToEmojis(b *testing.B) {
 		Letters: []*Letter{
 			{
 				Char:        'p',
-				Correctness: 2,
+				Correctness: Correct,
 			},
 			{
 				Char:        'a',
-				Correctness: 2,
+				Correctness: Correct,
 			},
 			{
 				Char:        'r',
-				Correctness: 2,
+				Correctness: Correct,
 			},
 			{
 				Char:        't',
-				Correctness: 2,
+				Correctness: Correct,
 			},
 			{
 				Char:        's',
-				Correctness: 2,
+				Correctness: Correct,
 			},
 		},
 	}
@@ -213,23 +321,23 @@ func BenchmarkConvertGuessToAnsiText(b *testing.B) {
 		Letters: []*Letter{
 			{
 				Char:        'p',
-				Correctness: 2,
+				Correctness: Correct,
 			},
 			{
 				Char:        'a',
-				Correctness: 2,
+				Correctness: Correct,
 			},
 			{
 				Char:        'r',
-				Correctness: 2,
+				Correctness: Correct,
 			},
 			{
 				Char:        't',
-				Correctness: 2,
+				Correctness: Correct,
 			},
 			{
 				Char:        's',
-				Correctness: 2,
+				Correctness: Correct,
 			},
 		},
 	}
@@ -0,0 +1,49 @@
+// Package jsonfile persists values as JSON files on disk, for the file-backed stores.
+package jsonfile
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Load reads the JSON file at the given path into v. A file that doesn't exist
+// or is empty is not an error, and leaves v untouched.
+func Load(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Save writes v as JSON to a temporary file first, and then renames it over
+// the file at the given path, so that a crash in the middle of a write can't
+// leave behind a corrupted file.
+func Save(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once the rename succeeds
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,49 @@
+package jsonfile
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	assert.NoError(t, Save(path, map[string]int{"a": 1}))
+	assert.NoError(t, Save(path, map[string]int{"b": 2})) // overwrites
+
+	var loaded map[string]int
+	assert.NoError(t, Load(path, &loaded))
+	assert.Equal(t, map[string]int{"b": 2}, loaded)
+
+	// no temporary files are left behind
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestLoadMissingOrEmpty(t *testing.T) {
+	dir := t.TempDir()
+	loaded := map[string]int{"untouched": 1}
+	assert.NoError(t, Load(filepath.Join(dir, "missing.json"), &loaded))
+
+	empty := filepath.Join(dir, "empty.json")
+	assert.NoError(t, ioutil.WriteFile(empty, nil, 0644))
+	assert.NoError(t, Load(empty, &loaded))
+	assert.Equal(t, map[string]int{"untouched": 1}, loaded)
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	var loaded map[string]int
+	assert.Error(t, Load(dir, &loaded)) // a directory
+
+	invalid := filepath.Join(dir, "invalid.json")
+	assert.NoError(t, ioutil.WriteFile(invalid, []byte("{"), 0644))
+	assert.Error(t, Load(invalid, &loaded))
+}
+
+func TestSaveError(t *testing.T) {
+	assert.Error(t, Save(filepath.Join(t.TempDir(), "missing", "data.json"), 1))
+}
@@ -0,0 +1,47 @@
+// Package puzzles keeps track of the custom puzzles that players create for each other.
+package puzzles
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// the letters that puzzle codes are made of. 0, O, 1 and I are left out, since
+// they're easy to mix up when a code is shared by hand
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// the number of letters in a puzzle code
+const codeLength = 6
+
+// Puzzle is a puzzle that a player created, with a word of their choosing.
+type Puzzle struct {
+	Code     string    // the code that the puzzle is started with, see NewCode
+	Word     string    // the solution, which must be an allowed guess
+	Language string    // code of the language that the word is in
+	Creator  string    // ID of the player that created the puzzle
+	Guild    string    // ID of the guild the puzzle was created in. empty for direct messages
+	Created  time.Time // when the puzzle was created
+}
+
+// NewCode generates a random code for a puzzle. Codes are random, rather than
+// counting up, so that nobody can play a puzzle that wasn't shared with them.
+func NewCode() (string, error) {
+	var b strings.Builder
+	max := big.NewInt(int64(len(codeAlphabet)))
+	for i := 0; i < codeLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(codeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+// NormalizeCode cleans up a code that a player typed in, so that codes aren't case
+// sensitive.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
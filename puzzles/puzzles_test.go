@@ -0,0 +1,28 @@
+package puzzles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCode(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		code, err := NewCode()
+		assert.NoError(t, err)
+		assert.Len(t, code, codeLength)
+		for _, c := range code {
+			assert.True(t, strings.ContainsRune(codeAlphabet, c), "%c isn't in the code alphabet", c)
+		}
+		seen[code] = true
+	}
+	// collisions are possible, but shouldn't happen this often
+	assert.Greater(t, len(seen), 90)
+}
+
+func TestNormalizeCode(t *testing.T) {
+	assert.Equal(t, "ABC234", NormalizeCode(" abc234 "))
+	assert.Equal(t, "ABC234", NormalizeCode("ABC234"))
+}
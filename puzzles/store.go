@@ -0,0 +1,95 @@
+package puzzles
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/saxypandabear/wordlego/internal/jsonfile"
+)
+
+// ErrCodeTaken is returned when adding a puzzle with the same code as another puzzle
+var ErrCodeTaken = errors.New("there is already a puzzle with that code")
+
+// Store keeps track of the custom puzzles, keyed by code. Implementations must be
+// safe to use from multiple goroutines.
+type Store interface {
+	// Get returns the puzzle with the given code, and whether or not it exists
+	Get(code string) (Puzzle, bool)
+	// Add saves a new puzzle. This returns ErrCodeTaken if the puzzle's code is already used
+	Add(p Puzzle) error
+}
+
+// MemoryStore is a Store that only keeps the puzzles in memory. All of the puzzles
+// are lost when the bot restarts.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	puzzles map[string]Puzzle
+}
+
+// NewMemoryStore creates an empty in-memory puzzle store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		puzzles: make(map[string]Puzzle),
+	}
+}
+
+func (m *MemoryStore) Get(code string) (Puzzle, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	p, ok := m.puzzles[code]
+	return p, ok
+}
+
+func (m *MemoryStore) Add(p Puzzle) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, taken := m.puzzles[p.Code]; taken {
+		return ErrCodeTaken
+	}
+	m.puzzles[p.Code] = p
+	return nil
+}
+
+// FileStore is a Store that writes the puzzles to a JSON file on disk every time
+// one is added, so that shared codes keep working after the bot restarts. The
+// puzzles are also kept in memory, so reads never touch the disk.
+type FileStore struct {
+	mu      sync.RWMutex
+	path    string
+	puzzles map[string]Puzzle
+}
+
+// NewFileStore creates a puzzle store that is backed by the JSON file at the given
+// path. If the file already exists, the puzzles in it are loaded into the store.
+// If it does not exist, it is created on the first write.
+func NewFileStore(path string) (*FileStore, error) {
+	fs := FileStore{
+		path:    path,
+		puzzles: make(map[string]Puzzle),
+	}
+	if err := jsonfile.Load(path, &fs.puzzles); err != nil {
+		return nil, err
+	}
+	return &fs, nil
+}
+
+func (f *FileStore) Get(code string) (Puzzle, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	p, ok := f.puzzles[code]
+	return p, ok
+}
+
+func (f *FileStore) Add(p Puzzle) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, taken := f.puzzles[p.Code]; taken {
+		return ErrCodeTaken
+	}
+	f.puzzles[p.Code] = p
+	if err := jsonfile.Save(f.path, f.puzzles); err != nil {
+		delete(f.puzzles, p.Code) // keep the memory in sync with the file
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package puzzles
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "puzzles.json"))
+	assert.NoError(t, err)
+	testStore(t, store)
+}
+
+func TestFileStoreSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "puzzles.json")
+	store, err := NewFileStore(path)
+	assert.NoError(t, err)
+	p := testPuzzle("ABC234")
+	assert.NoError(t, store.Add(p))
+
+	restarted, err := NewFileStore(path)
+	assert.NoError(t, err)
+	actual, ok := restarted.Get("ABC234")
+	assert.True(t, ok)
+	assert.Equal(t, p, actual)
+}
+
+func TestFileStoreFailedWrite(t *testing.T) {
+	// the parent directory doesn't exist, so the file can't be written
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "missing", "puzzles.json"))
+	assert.NoError(t, err)
+	assert.Error(t, store.Add(testPuzzle("ABC234")))
+	_, ok := store.Get("ABC234")
+	assert.False(t, ok)
+}
+
+func testStore(t *testing.T, store Store) {
+	_, ok := store.Get("ABC234")
+	assert.False(t, ok)
+
+	p := testPuzzle("ABC234")
+	assert.NoError(t, store.Add(p))
+	actual, ok := store.Get("ABC234")
+	assert.True(t, ok)
+	assert.Equal(t, p, actual)
+
+	// codes can't be reused
+	other := testPuzzle("ABC234")
+	other.Word = "hello"
+	assert.ErrorIs(t, store.Add(other), ErrCodeTaken)
+	actual, _ = store.Get("ABC234")
+	assert.Equal(t, p, actual)
+}
+
+func testPuzzle(code string) Puzzle {
+	return Puzzle{
+		Code:     code,
+		Word:     "party",
+		Language: "en",
+		Creator:  "creator",
+		Guild:    "guild",
+		Created:  time.Date(2022, time.February, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
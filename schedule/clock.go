@@ -0,0 +1,25 @@
+package schedule
+
+import "time"
+
+// Clock tells the time for the scheduler. This is an interface so that a fake
+// clock can be used in tests, rather than waiting for midnight.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// After waits for the duration to elapse, and then sends the current time on the channel
+	After(d time.Duration) <-chan time.Time
+}
+
+// RealClock is a Clock that uses the system time.
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
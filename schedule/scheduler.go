@@ -0,0 +1,76 @@
+package schedule
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/saxypandabear/wordlego/words"
+)
+
+// Target is a channel that the daily puzzle is announced in.
+type Target struct {
+	Guild   string // ID of the guild that the channel belongs to, to recap the guild's results
+	Channel string // ID of the channel to post in
+}
+
+// Scheduler posts a message when each new daily puzzle goes live at UTC midnight,
+// and a recap of the results of the puzzle that just ended.
+type Scheduler struct {
+	clock   Clock
+	targets func() []Target                       // where to announce, looked up at every announcement
+	post    func(channel, content string) error   // sends a message to a channel
+	recap   func(guild string, puzzle int) string // summarizes a guild's results for a puzzle
+}
+
+// New creates a scheduler that announces the daily puzzle in each of the targets,
+// using the given functions to post a message to a channel, and to summarize the
+// results for a puzzle in a guild. The targets are looked up at every announcement,
+// so they can change while the scheduler is running.
+func New(clock Clock, targets func() []Target, post func(channel, content string) error, recap func(guild string, puzzle int) string) *Scheduler {
+	return &Scheduler{
+		clock:   clock,
+		targets: targets,
+		post:    post,
+		recap:   recap,
+	}
+}
+
+// Run announces each daily puzzle at UTC midnight, until the stop channel is closed.
+// This blocks, so it should be run in its own goroutine.
+func (s *Scheduler) Run(stop <-chan struct{}) {
+	for {
+		now := s.clock.Now()
+		next := NextMidnight(now)
+		select {
+		case <-stop:
+			return
+		case <-s.clock.After(next.Sub(now)):
+			s.Announce(next)
+		}
+	}
+}
+
+// Announce posts the recap for the puzzle before the given day, followed by the
+// announcement of the given day's puzzle, in each of the targets.
+func (s *Scheduler) Announce(day time.Time) {
+	puzzle := words.DetermineWordForDay(day)
+	for _, t := range s.targets() {
+		if err := s.post(t.Channel, s.recap(t.Guild, puzzle-1)); err != nil {
+			log.Printf("Exception occurred when trying to post the recap for Wordle %d: %s\n", puzzle-1, err.Error())
+		}
+		if err := s.post(t.Channel, Announcement(puzzle)); err != nil {
+			log.Printf("Exception occurred when trying to announce Wordle %d: %s\n", puzzle, err.Error())
+		}
+	}
+}
+
+// Announcement returns the message that announces the puzzle.
+func Announcement(puzzle int) string {
+	return fmt.Sprintf("Wordle %d is live! Play it with /wordle start", puzzle)
+}
+
+// NextMidnight returns the next UTC midnight after the given time.
+func NextMidnight(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+}
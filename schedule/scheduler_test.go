@@ -0,0 +1,151 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/saxypandabear/wordlego/words"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock lets the test decide when time passes. Every call to After is sent
+// to the waits channel, and the test advances the clock by firing it.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits chan fakeWait
+}
+
+type fakeWait struct {
+	d  time.Duration
+	ch chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{
+		now:   now,
+		waits: make(chan fakeWait),
+	}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	c.waits <- fakeWait{d, ch}
+	return ch
+}
+
+// advance waits for the scheduler to wait on the clock, and then lets the time elapse.
+// This returns the duration that the scheduler waited for.
+func (c *fakeClock) advance(t *testing.T) time.Duration {
+	select {
+	case w := <-c.waits:
+		c.mu.Lock()
+		c.now = c.now.Add(w.d)
+		now := c.now
+		c.mu.Unlock()
+		w.ch <- now
+		return w.d
+	case <-time.After(time.Second):
+		t.Fatal("scheduler never waited on the clock")
+		return 0
+	}
+}
+
+type message struct {
+	channel string
+	content string
+}
+
+func TestNextMidnight(t *testing.T) {
+	assert.Equal(t, time.Date(2022, time.January, 6, 0, 0, 0, 0, time.UTC), NextMidnight(time.Date(2022, time.January, 5, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2022, time.January, 6, 0, 0, 0, 0, time.UTC), NextMidnight(time.Date(2022, time.January, 5, 0, 0, 0, 0, time.UTC)))
+	// converts to UTC first
+	est := time.FixedZone("EST", -5*60*60)
+	assert.Equal(t, time.Date(2022, time.January, 6, 0, 0, 0, 0, time.UTC), NextMidnight(time.Date(2022, time.January, 5, 18, 0, 0, 0, est)))
+	assert.Equal(t, time.Date(2022, time.January, 7, 0, 0, 0, 0, time.UTC), NextMidnight(time.Date(2022, time.January, 5, 20, 0, 0, 0, est)))
+}
+
+func TestSchedulerAnnouncesAtMidnight(t *testing.T) {
+	clock := newFakeClock(time.Date(2022, time.January, 5, 23, 0, 0, 0, time.UTC))
+	posts := make(chan message, 10)
+	targets := func() []Target {
+		return []Target{{Guild: "guild", Channel: "channel"}}
+	}
+	post := func(channel, content string) error {
+		posts <- message{channel, content}
+		return nil
+	}
+	recap := func(guild string, puzzle int) string {
+		return fmt.Sprintf("recap %s %d", guild, puzzle)
+	}
+	s := New(clock, targets, post, recap)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		s.Run(stop)
+		close(done)
+	}()
+
+	day := time.Date(2022, time.January, 6, 0, 0, 0, 0, time.UTC)
+	puzzle := words.DetermineWordForDay(day)
+	assert.Equal(t, time.Hour, clock.advance(t))
+	assert.Equal(t, message{"channel", fmt.Sprintf("recap guild %d", puzzle-1)}, <-posts)
+	assert.Equal(t, message{"channel", Announcement(puzzle)}, <-posts)
+
+	// then it waits for the next day
+	assert.Equal(t, 24*time.Hour, clock.advance(t))
+	assert.Equal(t, message{"channel", fmt.Sprintf("recap guild %d", puzzle)}, <-posts)
+	assert.Equal(t, message{"channel", Announcement(puzzle + 1)}, <-posts)
+
+	// stop while it's waiting
+	w := <-clock.waits
+	assert.Equal(t, 24*time.Hour, w.d)
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler didn't stop")
+	}
+	assert.Empty(t, posts)
+}
+
+func TestAnnounceEveryTarget(t *testing.T) {
+	var posts []message
+	targets := func() []Target {
+		return []Target{{Guild: "a", Channel: "1"}, {Guild: "b", Channel: "2"}}
+	}
+	post := func(channel, content string) error {
+		posts = append(posts, message{channel, content})
+		if channel == "1" {
+			return errors.New("missing permissions") // shouldn't stop the other targets
+		}
+		return nil
+	}
+	recap := func(guild string, puzzle int) string {
+		return "recap " + guild
+	}
+	day := time.Date(2022, time.January, 6, 0, 0, 0, 0, time.UTC)
+	New(RealClock, targets, post, recap).Announce(day)
+
+	puzzle := words.DetermineWordForDay(day)
+	assert.Equal(t, []message{
+		{"1", "recap a"},
+		{"1", Announcement(puzzle)},
+		{"2", "recap b"},
+		{"2", Announcement(puzzle)},
+	}, posts)
+}
+
+func TestAnnouncement(t *testing.T) {
+	assert.Equal(t, "Wordle 200 is live! Play it with /wordle start", Announcement(200))
+}
@@ -0,0 +1,278 @@
+// Package solver works out how much each guess of a game narrowed down the solution,
+// and what the best guess would have been, for analyzing games after they're over.
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/saxypandabear/wordlego/guess"
+	"github.com/saxypandabear/wordlego/words"
+)
+
+// MaxLength is the longest word that a solver can be built for, since the feedback for a
+// word has to fit in a Pattern.
+const MaxLength = 5
+
+// the number of different patterns that there can be for words of MaxLength letters
+const patternCount = 243 // 3^MaxLength
+
+// Pattern is the feedback for a guess against a solution, as a number that has the
+// guess.Correctness of each letter as a digit in base 3, with the first letter as the
+// lowest digit. A letter that is absent is 0, present is 1 and correct is 2.
+type Pattern uint8
+
+// ErrUnknownSolution is returned when a game is analyzed with a solution that isn't one
+// of the solver's solutions, so the candidates wouldn't make any sense.
+var ErrUnknownSolution = errors.New("the solution isn't one of the solver's solutions")
+
+// PatternOf returns the feedback for the word against the solution, as scored by
+// guess.ConvertToGuess. The word and the solution must be the same length.
+func PatternOf(word, solution string) (Pattern, error) {
+	g, err := guess.ConvertToGuess(word, solution)
+	if err != nil {
+		return 0, err
+	}
+	return patternOfGuess(g), nil
+}
+
+// patternOfGuess returns the pattern of the feedback that the guess got.
+func patternOfGuess(g *guess.Guess) Pattern {
+	var p, digit Pattern = 0, 1
+	for _, l := range g.Letters {
+		p += Pattern(l.Correctness-guess.Absent) * digit
+		digit *= 3
+	}
+	return p
+}
+
+// Solver knows the feedback of every allowed guess against every solution, so that it
+// can quickly narrow down the solutions that are left after each guess, and measure how
+// much information a guess gives. The feedback is worked out once, when the solver is
+// built, which takes a while for the full lists of words, see Default.
+type Solver struct {
+	solutions []string       // the words that can be solutions, in the order of the table's columns
+	guesses   []string       // every allowed guess, starting with the solutions, in the order of the table's rows
+	index     map[string]int // the row of each guess in the table
+	table     [][]Pattern    // the feedback of each guess against each solution
+}
+
+// New builds a solver for the solutions, with the allowed guesses, which are in addition
+// to the solutions. All of the words must have the same number of letters, up to MaxLength.
+func New(solutions, allowed []string) (*Solver, error) {
+	if len(solutions) == 0 {
+		return nil, errors.New("a solver needs at least one solution")
+	}
+	length := utf8.RuneCountInString(solutions[0])
+	if length > MaxLength {
+		return nil, fmt.Errorf("a solver can only be built for words of up to %d letters, not %d", MaxLength, length)
+	}
+	s := &Solver{
+		solutions: solutions,
+		index:     make(map[string]int),
+	}
+	for _, list := range [][]string{solutions, allowed} {
+		for _, w := range list {
+			if n := utf8.RuneCountInString(w); n != length {
+				return nil, fmt.Errorf("'%s' has %d letters, but the solutions have %d", w, n, length)
+			}
+			if _, ok := s.index[w]; ok {
+				if len(s.guesses) < len(solutions) {
+					return nil, fmt.Errorf("'%s' is in the solutions more than once", w)
+				}
+				continue
+			}
+			s.index[w] = len(s.guesses)
+			s.guesses = append(s.guesses, w)
+		}
+	}
+	s.table = make([][]Pattern, len(s.guesses))
+	s.fillTable()
+	return s, nil
+}
+
+// fillTable works out the feedback of every guess against every solution, spreading the
+// guesses across all of the CPUs.
+func (s *Solver) fillTable() {
+	workers := runtime.NumCPU()
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(first int) {
+			defer wg.Done()
+			for g := first; g < len(s.guesses); g += workers {
+				row := make([]Pattern, len(s.solutions))
+				for i, sol := range s.solutions {
+					row[i], _ = PatternOf(s.guesses[g], sol) // the lengths were already checked
+				}
+				s.table[g] = row
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+var (
+	defaultSolver *Solver
+	defaultOnce   sync.Once
+)
+
+// Default returns the solver for the usual five letter English puzzles, with all of the
+// solutions and allowed guesses of the active word bank, see words.UseWordBank. It's
+// built the first time that it's needed, which takes a while, so it can be warmed up
+// ahead of time by calling this in the background, once the word banks are loaded.
+func Default() *Solver {
+	defaultOnce.Do(func() {
+		bank, ok := words.GetWordBank(words.DefaultLanguage, words.DefaultLength)
+		if !ok {
+			panic("there is no five letter English word bank") // the built-in one is never removed
+		}
+		var err error
+		if defaultSolver, err = fromBank(bank); err != nil {
+			panic(err) // the word banks are validated when they're created
+		}
+	})
+	return defaultSolver
+}
+
+// fromBank builds a solver with all of the solutions and allowed guesses of the bank.
+func fromBank(bank words.WordBank) (*Solver, error) {
+	return New(bank.Solutions(), bank.Allowed())
+}
+
+// IsSolution returns whether the word is one of the solver's solutions.
+func (s *Solver) IsSolution(word string) bool {
+	row, ok := s.index[word]
+	return ok && row < len(s.solutions)
+}
+
+// all returns every solution as a candidate.
+func (s *Solver) all() []int {
+	candidates := make([]int, len(s.solutions))
+	for i := range candidates {
+		candidates[i] = i
+	}
+	return candidates
+}
+
+// patterns returns the feedback for the word against each of the solutions, which comes
+// from the table if the word is an allowed guess.
+func (s *Solver) patterns(word string) ([]Pattern, error) {
+	if row, ok := s.index[word]; ok {
+		return s.table[row], nil
+	}
+	row := make([]Pattern, len(s.solutions))
+	for i, sol := range s.solutions {
+		var err error
+		if row[i], err = PatternOf(word, sol); err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
+
+// filter returns the candidates that would give the guess with the patterns the same
+// feedback.
+func filter(patterns []Pattern, candidates []int, feedback Pattern) []int {
+	var left []int
+	for _, c := range candidates {
+		if patterns[c] == feedback {
+			left = append(left, c)
+		}
+	}
+	return left
+}
+
+// bits returns the expected information, in bits, that the guess with the patterns gives
+// when the solution is one of the candidates, which are all equally likely. A guess that
+// splits the candidates into more, evenly sized groups gives more information.
+func bits(patterns []Pattern, candidates []int) float64 {
+	var counts [patternCount]int
+	for _, c := range candidates {
+		counts[patterns[c]]++
+	}
+	total := float64(len(candidates))
+	bits := 0.0
+	for _, n := range counts {
+		if n > 0 {
+			p := float64(n) / total
+			bits -= p * math.Log2(p)
+		}
+	}
+	return bits
+}
+
+// best returns the guess that gives the most information about the candidates, and how
+// much it gives. On a tie, a guess that could be the solution is better, since it could
+// win right away. When there's only one candidate left, it's the best guess.
+func (s *Solver) best(candidates []int) (string, float64) {
+	if len(candidates) == 1 {
+		return s.solutions[candidates[0]], 0
+	}
+	isCandidate := make(map[int]bool, len(candidates))
+	for _, c := range candidates {
+		isCandidate[c] = true
+	}
+	const epsilon = 1e-9
+	bestRow, bestBits := -1, 0.0
+	for row, patterns := range s.table {
+		b := bits(patterns, candidates)
+		switch {
+		case bestRow < 0 || b > bestBits+epsilon:
+		case b > bestBits-epsilon && isCandidate[row] && !isCandidate[bestRow]:
+		default:
+			continue
+		}
+		bestRow, bestBits = row, b
+	}
+	return s.guesses[bestRow], bestBits
+}
+
+// Step is the analysis of one guess of a game.
+type Step struct {
+	Guess    string  // the word that was guessed
+	Before   int     // the number of candidate solutions before the guess
+	After    int     // the number of candidate solutions that were left after the guess
+	Bits     float64 // the expected information that the guess gave, in bits
+	Best     string  // the guess that would have given the most information
+	BestBits float64 // the expected information that the best guess would have given, in bits
+}
+
+// Analyze replays the guesses of a game with the solution, and works out how many
+// candidate solutions were left after each guess, how much information each guess was
+// expected to give, and what the best guess would have been instead. The guesses don't
+// have to be allowed guesses, but they have to be the same length as the solution.
+// This returns ErrUnknownSolution if the solution isn't one of the solver's solutions.
+func (s *Solver) Analyze(guesses []string, solution string) ([]Step, error) {
+	if !s.IsSolution(solution) {
+		return nil, ErrUnknownSolution
+	}
+	candidates := s.all()
+	steps := make([]Step, 0, len(guesses))
+	for _, word := range guesses {
+		feedback, err := PatternOf(word, solution)
+		if err != nil {
+			return nil, err
+		}
+		patterns, err := s.patterns(word)
+		if err != nil {
+			return nil, err
+		}
+		best, bestBits := s.best(candidates)
+		left := filter(patterns, candidates, feedback)
+		steps = append(steps, Step{
+			Guess:    word,
+			Before:   len(candidates),
+			After:    len(left),
+			Bits:     bits(patterns, candidates),
+			Best:     best,
+			BestBits: bestBits,
+		})
+		candidates = left
+	}
+	return steps, nil
+}
@@ -0,0 +1,161 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/saxypandabear/wordlego/words"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testSolutions = []string{"party", "pants", "beams", "crane", "moist", "tarts"}
+	testAllowed   = []string{"pudgy", "crane", "scamp"}
+)
+
+func TestPatternOf(t *testing.T) {
+	p, err := PatternOf("party", "party")
+	assert.NoError(t, err)
+	assert.Equal(t, Pattern(patternCount-1), p)
+
+	p, err = PatternOf("pudgy", "beams")
+	assert.NoError(t, err)
+	assert.Equal(t, Pattern(0), p)
+
+	// p, a and t are correct, n and s are absent
+	p, err = PatternOf("pants", "party")
+	assert.NoError(t, err)
+	assert.Equal(t, Pattern(2+2*3+0*9+2*27+0*81), p)
+
+	// t is correct, p and a are present
+	p, err = PatternOf("tapir", "party")
+	assert.NoError(t, err)
+	assert.Equal(t, Pattern(1+2*3+1*9+0*27+1*81), p)
+
+	_, err = PatternOf("part", "party")
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	s, err := New(testSolutions, testAllowed)
+	assert.NoError(t, err)
+	// crane is both a solution and an allowed guess, but it's only in the table once
+	assert.Len(t, s.guesses, 8)
+	assert.Len(t, s.table, 8)
+	assert.True(t, s.IsSolution("crane"))
+	assert.False(t, s.IsSolution("pudgy"))
+	assert.False(t, s.IsSolution("nope"))
+
+	_, err = New(nil, testAllowed)
+	assert.Error(t, err)
+	_, err = New([]string{"garden"}, nil)
+	assert.Error(t, err)
+	_, err = New(testSolutions, []string{"part"})
+	assert.Error(t, err)
+	_, err = New([]string{"party", "party"}, nil)
+	assert.Error(t, err)
+}
+
+func TestAnalyze(t *testing.T) {
+	s, err := New(testSolutions, testAllowed)
+	assert.NoError(t, err)
+
+	steps, err := s.Analyze([]string{"pudgy", "pants", "party"}, "party")
+	assert.NoError(t, err)
+	assert.Len(t, steps, 3)
+
+	// party is the only candidate with y at the end
+	assert.Equal(t, "pudgy", steps[0].Guess)
+	assert.Equal(t, 6, steps[0].Before)
+	assert.Equal(t, 1, steps[0].After)
+	assert.Greater(t, steps[0].Bits, 0.0)
+	assert.GreaterOrEqual(t, steps[0].BestBits, steps[0].Bits)
+
+	// there's only one candidate left, so it's the best guess
+	assert.Equal(t, 1, steps[1].Before)
+	assert.Equal(t, 1, steps[1].After)
+	assert.Equal(t, 0.0, steps[1].Bits)
+	assert.Equal(t, "party", steps[1].Best)
+	assert.Equal(t, "party", steps[2].Best)
+
+	// a guess that isn't allowed still counts, and moist is the only word without any of its letters
+	steps, err = s.Analyze([]string{"abcde"}, "moist")
+	assert.NoError(t, err)
+	assert.Equal(t, 6, steps[0].Before)
+	assert.Equal(t, 1, steps[0].After)
+
+	_, err = s.Analyze([]string{"party"}, "pudgy")
+	assert.ErrorIs(t, err, ErrUnknownSolution)
+	_, err = s.Analyze([]string{"part"}, "party")
+	assert.Error(t, err)
+}
+
+func TestBest(t *testing.T) {
+	// every guess splits the two candidates apart, so the one that could win is best
+	s, err := New([]string{"party", "pants"}, []string{"abcde", "rrrrr"})
+	assert.NoError(t, err)
+	best, bits := s.best(s.all())
+	assert.Equal(t, "party", best)
+	assert.InDelta(t, 1.0, bits, 1e-9)
+
+	// a guess that splits the candidates evenly beats one that could win
+	s, err = New([]string{"batch", "catch", "hatch", "latch"}, []string{"cbhlz"})
+	assert.NoError(t, err)
+	best, bits = s.best(s.all())
+	assert.Equal(t, "cbhlz", best)
+	assert.InDelta(t, 2.0, bits, 1e-9)
+}
+
+/* benchmark tests for fun */
+
+func TestFromBank(t *testing.T) {
+	base, err := words.NewListBank(testSolutions, testAllowed)
+	assert.NoError(t, err)
+	extra, err := words.NewListBank(nil, []string{"zymes"})
+	assert.NoError(t, err)
+	bank, err := words.Extend(base, extra)
+	assert.NoError(t, err)
+
+	s, err := fromBank(bank)
+	assert.NoError(t, err)
+	assert.Equal(t, testSolutions, s.solutions)
+	assert.True(t, s.IsSolution("party"))
+	assert.False(t, s.IsSolution("scamp"))
+	// the guesses that the bank was extended with are in the table too
+	assert.Len(t, s.guesses, 9)
+	_, ok := s.index["zymes"]
+	assert.True(t, ok)
+}
+
+func BenchmarkPatternOf(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		PatternOf("crane", "party")
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		New(words.Solutions[:200], words.AllowedWords[:1000])
+	}
+}
+
+// BenchmarkNewFull builds the solver for the full lists of words, like Default, which
+// is what the time and memory that the README gives for the solver are based on. Most
+// of the allocations are garbage from scoring the guesses, so the memory that the solver
+// keeps is reported as the size of its table.
+func BenchmarkNewFull(b *testing.B) {
+	b.ReportAllocs()
+	var s *Solver
+	for i := 0; i < b.N; i++ {
+		s, _ = New(words.Solutions, words.AllowedWords)
+	}
+	b.ReportMetric(float64(len(s.guesses)*len(s.solutions))/1e6, "table-MB")
+}
+
+func BenchmarkAnalyze(b *testing.B) {
+	s, _ := New(words.Solutions[:200], words.AllowedWords[:1000])
+	guesses := []string{"crane", "moist", words.Solutions[100]}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Analyze(guesses, words.Solutions[100])
+	}
+}
@@ -0,0 +1,136 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Window is the range of daily puzzles that a leaderboard covers
+type Window string
+
+const (
+	Today   Window = "today" // only the puzzle of the day
+	Week    Window = "week"  // the last 7 daily puzzles, including today's
+	Month   Window = "month" // the last 30 daily puzzles, including today's
+	AllTime Window = "all"   // every daily puzzle
+)
+
+// the number of players that are shown on a leaderboard
+const leaderboardSize = 10
+
+// contains returns whether the puzzle falls within the window, given the puzzle of the day.
+func (w Window) contains(puzzle, today int) bool {
+	switch w {
+	case Today:
+		return puzzle == today
+	case Week:
+		return puzzle > today-7 && puzzle <= today
+	case Month:
+		return puzzle > today-30 && puzzle <= today
+	default:
+		return puzzle <= today
+	}
+}
+
+// Standing is a player's position on a leaderboard.
+type Standing struct {
+	Player    string
+	Played    int
+	Wins      int
+	Guesses   int           // total number of guesses used in the games that were won
+	SolveTime time.Duration // total time taken for the games that were won
+}
+
+// AverageGuesses returns the average number of guesses it took the player to win.
+func (st Standing) AverageGuesses() float64 {
+	if st.Wins == 0 {
+		return 0
+	}
+	return float64(st.Guesses) / float64(st.Wins)
+}
+
+// Leaderboard ranks the players of the guild by their results for the daily puzzles
+// within the window, given the puzzle of the day. Players are ranked by:
+// 1. the most wins
+// 2. the fewest guesses used on average, to win
+// 3. the least time taken in total, to win
+// Only the results for the daily puzzles count, so replays of older puzzles don't
+// affect the leaderboard. Only the first result for each player for each puzzle
+// counts, so giving up and starting over doesn't affect it either.
+func Leaderboard(results []Result, guild string, today int, window Window) []Standing {
+	type played struct {
+		player string
+		puzzle int
+	}
+	seen := make(map[played]bool)
+	byPlayer := make(map[string]*Standing)
+	var standings []*Standing
+	for _, r := range results {
+		if r.Guild != guild || !r.Daily || !window.contains(r.Puzzle, today) {
+			continue
+		}
+		key := played{r.Player, r.Puzzle}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		st, ok := byPlayer[r.Player]
+		if !ok {
+			st = &Standing{Player: r.Player}
+			byPlayer[r.Player] = st
+			standings = append(standings, st)
+		}
+		st.Played++
+		if r.Outcome == Win {
+			st.Wins++
+			st.Guesses += r.Guesses
+			st.SolveTime += r.SolveTime()
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		if a.AverageGuesses() != b.AverageGuesses() {
+			return a.AverageGuesses() < b.AverageGuesses()
+		}
+		return a.SolveTime < b.SolveTime
+	})
+	ranked := make([]Standing, len(standings))
+	for i, st := range standings {
+		ranked[i] = *st
+	}
+	return ranked
+}
+
+// FormatLeaderboard returns a string that shows the top of the leaderboard, to display
+// in Discord. Players are mentioned by their ID, see https://discord.com/developers/docs/reference#message-formatting
+func FormatLeaderboard(title string, standings []Standing, window Window) string {
+	var b strings.Builder
+	b.WriteString(title + "\n")
+	if len(standings) == 0 {
+		b.WriteString("Nobody has played yet!")
+		return b.String()
+	}
+	for i, st := range standings {
+		if i == leaderboardSize {
+			break
+		}
+		b.WriteString(fmt.Sprintf("%d. <@%s> ", i+1, st.Player))
+		switch {
+		case window == Today && st.Wins == 0:
+			b.WriteString("X")
+		case window == Today:
+			b.WriteString(fmt.Sprintf("%d guesses in %s", st.Guesses, st.SolveTime.Round(time.Second)))
+		default:
+			b.WriteString(fmt.Sprintf("%d/%d wins, %.2f average guesses", st.Wins, st.Played, st.AverageGuesses()))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,119 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2022, time.January, 5, 12, 0, 0, 0, time.UTC)
+
+func daily(player string, puzzle, guesses int, outcome Outcome, solveTime time.Duration) Result {
+	return Result{
+		Player:   player,
+		Guild:    "guild",
+		Puzzle:   puzzle,
+		Daily:    true,
+		Guesses:  guesses,
+		Outcome:  outcome,
+		Started:  start,
+		Finished: start.Add(solveTime),
+	}
+}
+
+func TestLeaderboardToday(t *testing.T) {
+	results := []Result{
+		daily("slow", 200, 3, Win, time.Hour),
+		daily("fast", 200, 3, Win, time.Minute),
+		daily("lucky", 200, 1, Win, time.Hour),
+		daily("loser", 200, 6, Loss, time.Minute),
+		daily("yesterday", 199, 1, Win, time.Minute),
+	}
+	standings := Leaderboard(results, "guild", 200, Today)
+	var players []string
+	for _, st := range standings {
+		players = append(players, st.Player)
+	}
+	assert.Equal(t, []string{"lucky", "fast", "slow", "loser"}, players)
+	assert.Equal(t, Standing{Player: "fast", Played: 1, Wins: 1, Guesses: 3, SolveTime: time.Minute}, standings[1])
+	assert.Equal(t, Standing{Player: "loser", Played: 1}, standings[3])
+}
+
+func TestLeaderboardIgnoresOtherResults(t *testing.T) {
+	replay := daily("replay", 100, 1, Win, time.Minute)
+	replay.Daily = false
+	otherGuild := daily("other", 200, 1, Win, time.Minute)
+	otherGuild.Guild = "other-guild"
+	dm := daily("dm", 200, 1, Win, time.Minute)
+	dm.Guild = ""
+	results := []Result{
+		replay,
+		otherGuild,
+		dm,
+		daily("player", 200, 5, Forfeit, time.Minute),
+		daily("player", 200, 1, Win, time.Minute), // started over after giving up
+	}
+	standings := Leaderboard(results, "guild", 200, AllTime)
+	assert.Equal(t, []Standing{{Player: "player", Played: 1}}, standings)
+}
+
+func TestLeaderboardWindows(t *testing.T) {
+	results := []Result{
+		daily("player", 100, 2, Win, time.Minute),
+		daily("player", 180, 4, Win, time.Minute),
+		daily("player", 195, 6, Loss, time.Minute),
+		daily("player", 200, 3, Win, time.Minute),
+		daily("player", 201, 3, Win, time.Minute), // a day that hasn't happened yet shouldn't count
+	}
+	expected := map[Window]Standing{
+		Today:   {Player: "player", Played: 1, Wins: 1, Guesses: 3, SolveTime: time.Minute},
+		Week:    {Player: "player", Played: 2, Wins: 1, Guesses: 3, SolveTime: time.Minute},
+		Month:   {Player: "player", Played: 3, Wins: 2, Guesses: 7, SolveTime: 2 * time.Minute},
+		AllTime: {Player: "player", Played: 4, Wins: 3, Guesses: 9, SolveTime: 3 * time.Minute},
+	}
+	for window, st := range expected {
+		t.Run(string(window), func(t *testing.T) {
+			assert.Equal(t, []Standing{st}, Leaderboard(results, "guild", 200, window))
+		})
+	}
+}
+
+func TestLeaderboardRanksByWinsThenAverage(t *testing.T) {
+	results := []Result{
+		daily("consistent", 199, 4, Win, time.Minute),
+		daily("consistent", 200, 4, Win, time.Minute),
+		daily("streaky", 199, 2, Win, time.Minute),
+		daily("streaky", 200, 6, Loss, time.Minute),
+		daily("efficient", 199, 3, Win, time.Minute),
+		daily("efficient", 200, 3, Win, time.Minute),
+	}
+	standings := Leaderboard(results, "guild", 200, Week)
+	assert.Equal(t, "efficient", standings[0].Player)
+	assert.Equal(t, "consistent", standings[1].Player)
+	assert.Equal(t, "streaky", standings[2].Player)
+	assert.Equal(t, 3.0, standings[0].AverageGuesses())
+	assert.Equal(t, 0.0, Standing{}.AverageGuesses())
+}
+
+func TestFormatLeaderboard(t *testing.T) {
+	standings := []Standing{
+		{Player: "1", Played: 1, Wins: 1, Guesses: 3, SolveTime: 90*time.Second + 400*time.Millisecond},
+		{Player: "2", Played: 1},
+	}
+	assert.Equal(t, "Today\n1. <@1> 3 guesses in 1m30s\n2. <@2> X\n", FormatLeaderboard("Today", standings, Today))
+
+	standings = []Standing{
+		{Player: "1", Played: 3, Wins: 2, Guesses: 7},
+	}
+	assert.Equal(t, "Week\n1. <@1> 2/3 wins, 3.50 average guesses\n", FormatLeaderboard("Week", standings, Week))
+
+	assert.Equal(t, "Empty\nNobody has played yet!", FormatLeaderboard("Empty", nil, Today))
+}
+
+func TestFormatLeaderboardShowsTopPlayers(t *testing.T) {
+	standings := make([]Standing, leaderboardSize+5)
+	formatted := FormatLeaderboard("Title", standings, AllTime)
+	assert.Equal(t, leaderboardSize+1, strings.Count(formatted, "\n"))
+}
@@ -0,0 +1,61 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Discord doesn't allow messages longer than this many characters
+const maxMessageLength = 2000
+
+// FormatRecap returns a string that summarizes the guild's results for the daily
+// puzzle, with each player's emoji grid, to display in Discord at the end of the day.
+// Only the first result for each player counts, like on the leaderboard. Players are
+// listed in the order they finished, and the players that don't fit within Discord's
+// message limit are summarized at the end.
+func FormatRecap(results []Result, guild string, puzzle int) string {
+	var recap []Result
+	seen := make(map[string]bool)
+	solved := 0
+	for _, r := range results {
+		if r.Guild != guild || !r.Daily || r.Puzzle != puzzle || seen[r.Player] {
+			continue
+		}
+		seen[r.Player] = true
+		recap = append(recap, r)
+		if r.Outcome == Win {
+			solved++
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Wordle %d recap: %d of %d players solved it\n", puzzle, solved, len(recap)))
+	if len(recap) == 0 {
+		b.WriteString("Nobody played!")
+		return b.String()
+	}
+	for i, r := range recap {
+		score := "X"
+		if r.Outcome == Win {
+			score = fmt.Sprint(r.Guesses)
+		}
+		hardMode := ""
+		if r.HardMode {
+			hardMode = "*"
+		}
+		hinted := ""
+		if r.Hinted {
+			hinted = " (hinted)"
+		}
+		entry := fmt.Sprintf("<@%s> %s/%d%s%s\n%s\n", r.Player, score, r.MaxGuesses, hardMode, hinted, r.Grid)
+
+		more := fmt.Sprintf("...and %d more", len(recap)-i)
+		if utf8.RuneCountInString(b.String()+entry+more) > maxMessageLength {
+			b.WriteString(more)
+			break
+		}
+		b.WriteString(entry)
+	}
+	return b.String()
+}
@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatRecap(t *testing.T) {
+	hard := daily("hard", 200, 3, Win, time.Minute)
+	hard.HardMode = true
+	hinted := daily("hinted", 200, 5, Win, time.Minute)
+	hinted.Hinted = true
+	results := []Result{
+		daily("first", 200, 4, Win, time.Minute),
+		daily("loser", 200, 6, Loss, time.Minute),
+		hard,
+		hinted,
+		daily("first", 200, 1, Win, time.Minute), // only the first attempt counts
+		daily("other-day", 199, 1, Win, time.Minute),
+	}
+	for i := range results {
+		results[i].MaxGuesses = 6
+		results[i].Grid = strings.Repeat("🟩", 5)
+	}
+
+	expected := strings.Join([]string{
+		"Wordle 200 recap: 3 of 4 players solved it",
+		"<@first> 4/6",
+		"🟩🟩🟩🟩🟩",
+		"<@loser> X/6",
+		"🟩🟩🟩🟩🟩",
+		"<@hard> 3/6*",
+		"🟩🟩🟩🟩🟩",
+		"<@hinted> 5/6 (hinted)",
+		"🟩🟩🟩🟩🟩",
+		"",
+	}, "\n")
+	assert.Equal(t, expected, FormatRecap(results, "guild", 200))
+}
+
+func TestFormatRecapNobodyPlayed(t *testing.T) {
+	results := []Result{daily("other-day", 199, 1, Win, time.Minute)}
+	assert.Equal(t, "Wordle 200 recap: 0 of 0 players solved it\nNobody played!", FormatRecap(results, "guild", 200))
+}
+
+func TestFormatRecapFitsInOneMessage(t *testing.T) {
+	var results []Result
+	for i := 0; i < 100; i++ {
+		r := daily(strings.Repeat("1", 18), 200, 6, Win, time.Minute)
+		r.Player += string(rune('a' + i%26))
+		r.Player += string(rune('a' + i/26))
+		r.MaxGuesses = 6
+		r.Grid = strings.TrimSuffix(strings.Repeat("🟩🟩🟩🟩🟩\n", 6), "\n")
+		results = append(results, r)
+	}
+	recap := FormatRecap(results, "guild", 200)
+	assert.LessOrEqual(t, len([]rune(recap)), maxMessageLength)
+	assert.True(t, strings.HasPrefix(recap, "Wordle 200 recap: 100 of 100 players solved it\n"))
+	assert.Regexp(t, `\.\.\.and \d+ more$`, recap)
+}
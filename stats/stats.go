@@ -0,0 +1,140 @@
+package stats
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Outcome is how a game ended
+type Outcome string
+
+const (
+	Win     Outcome = "win"     // the player guessed the solution
+	Loss    Outcome = "loss"    // the player ran out of guesses
+	Forfeit Outcome = "forfeit" // the player gave up
+)
+
+// the number of rows that the guess distribution always shows, like in Wordle
+const distributionRows = 6
+
+// the maximum width of a bar in the guess distribution histogram
+const maxBarWidth = 20
+
+// Result is the record of a single completed game.
+type Result struct {
+	Player     string    // ID of the player that played the game
+	Puzzle     int       // the number of the Wordle puzzle
+	Guesses    int       // the number of guesses the player used, where each hint counts as one
+	MaxGuesses int       // the maximum number of guesses the player was allowed, before taking any hints
+	HardMode   bool      // whether the game was played in hard mode
+	Outcome    Outcome   // how the game ended
+	Grid       string    // the guesses as emojis, one guess per line
+	Guild      string    // ID of the guild the game was played in. empty for direct messages
+	Daily      bool      // whether the game was the puzzle of the day, rather than a replay of an older puzzle
+	Practice   bool      // whether the game was for practice, which doesn't count towards the statistics
+	Custom     string    // the code of the custom puzzle that the game was for, which doesn't count towards the statistics. empty otherwise
+	Hinted     bool      // whether the player took any hints, which each cost a guess
+	Language   string    // the code of the language that the game was played in
+	Solution   string    // the word that the player had to guess
+	Started    time.Time // when the game started
+	Finished   time.Time // when the game ended
+}
+
+// SolveTime returns how long the player took to finish the game.
+func (r Result) SolveTime() time.Duration {
+	return r.Finished.Sub(r.Started)
+}
+
+// Stats is the summary of all of a player's completed games.
+type Stats struct {
+	Played        int
+	Wins          int
+	CurrentStreak int   // the number of wins in a row, up to the most recent game
+	MaxStreak     int   // the most wins in a row, ever
+	Hinted        int   // the number of games that the player took hints in
+	Distribution  []int // number of wins by guesses used, where index 0 is a win in 1 guess
+}
+
+// Compute summarizes the results, which are expected to be in the order that
+// the games were completed. A loss or a forfeit ends the current streak. Practice
+// games and custom puzzles are left out entirely.
+func Compute(results []Result) Stats {
+	st := Stats{
+		Distribution: make([]int, distributionRows),
+	}
+	for _, r := range results {
+		if r.Practice || r.Custom != "" {
+			continue
+		}
+		st.Played++
+		if r.Hinted {
+			st.Hinted++
+		}
+		if r.Outcome != Win {
+			st.CurrentStreak = 0
+			continue
+		}
+		st.Wins++
+		st.CurrentStreak++
+		if st.CurrentStreak > st.MaxStreak {
+			st.MaxStreak = st.CurrentStreak
+		}
+		if r.Guesses < 1 {
+			continue
+		}
+		// games can be configured with more guesses than usual, so grow the distribution to fit
+		for len(st.Distribution) < r.Guesses {
+			st.Distribution = append(st.Distribution, 0)
+		}
+		st.Distribution[r.Guesses-1]++
+	}
+	return st
+}
+
+// WinPercentage returns the percentage of games played that were won, rounded
+// down to a whole number like in Wordle.
+func (st Stats) WinPercentage() int {
+	if st.Played == 0 {
+		return 0
+	}
+	return st.Wins * 100 / st.Played
+}
+
+// Format returns a string that shows the stats, along with a histogram of the
+// guess distribution, to display in Discord. The string is enclosed in a code
+// block so that the histogram lines up.
+func (st Stats) Format() string {
+	var b strings.Builder
+	b.WriteString("```\n")
+	b.WriteString(fmt.Sprintf("Played: %d | Win %%: %d | Current streak: %d | Max streak: %d",
+		st.Played, st.WinPercentage(), st.CurrentStreak, st.MaxStreak))
+	if st.Hinted > 0 {
+		b.WriteString(fmt.Sprintf(" | Hinted: %d", st.Hinted))
+	}
+	b.WriteString("\n")
+	b.WriteString("Guess distribution\n")
+	most := 0
+	for _, count := range st.Distribution {
+		if count > most {
+			most = count
+		}
+	}
+	width := len(fmt.Sprint(len(st.Distribution))) // so the bars line up past 9 guesses
+	for i, count := range st.Distribution {
+		bar := 0
+		if most > 0 {
+			bar = count * maxBarWidth / most
+		}
+		if bar == 0 && count > 0 {
+			bar = 1 // always show something for a count that isn't zero
+		}
+		b.WriteString(fmt.Sprintf("%*d | ", width, i+1))
+		if bar > 0 {
+			b.WriteString(strings.Repeat("█", bar) + " ")
+		}
+		b.WriteString(fmt.Sprintf("%d\n", count))
+	}
+	b.WriteString("```")
+	return b.String()
+}
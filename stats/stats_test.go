@@ -0,0 +1,117 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeNoResults(t *testing.T) {
+	st := Compute(nil)
+	assert.Equal(t, Stats{Distribution: make([]int, 6)}, st)
+	assert.Equal(t, 0, st.WinPercentage())
+}
+
+func TestCompute(t *testing.T) {
+	results := []Result{
+		{Outcome: Win, Guesses: 3},
+		{Outcome: Win, Guesses: 4},
+		{Outcome: Win, Guesses: 4},
+		{Outcome: Loss, Guesses: 6},
+		{Outcome: Win, Guesses: 1},
+		{Outcome: Forfeit, Guesses: 2},
+		{Outcome: Win, Guesses: 6},
+	}
+	st := Compute(results)
+	assert.Equal(t, 7, st.Played)
+	assert.Equal(t, 5, st.Wins)
+	assert.Equal(t, 1, st.CurrentStreak)
+	assert.Equal(t, 3, st.MaxStreak)
+	assert.Equal(t, []int{1, 0, 1, 2, 0, 1}, st.Distribution)
+	assert.Equal(t, 71, st.WinPercentage()) // rounded down
+}
+
+func TestComputeMoreThanSixGuesses(t *testing.T) {
+	results := []Result{
+		{Outcome: Win, Guesses: 2},
+		{Outcome: Win, Guesses: 8, MaxGuesses: 10},
+	}
+	st := Compute(results)
+	assert.Equal(t, []int{0, 1, 0, 0, 0, 0, 0, 1}, st.Distribution)
+	assert.Equal(t, 2, st.CurrentStreak)
+	assert.Equal(t, 100, st.WinPercentage())
+}
+
+func TestComputeSkipsPractice(t *testing.T) {
+	results := []Result{
+		{Outcome: Win, Guesses: 2},
+		{Outcome: Loss, Guesses: 6, Practice: true},
+		{Outcome: Win, Guesses: 1, Practice: true},
+		{Outcome: Win, Guesses: 3},
+	}
+	st := Compute(results)
+	assert.Equal(t, 2, st.Played)
+	assert.Equal(t, 2, st.CurrentStreak)
+	assert.Equal(t, []int{0, 1, 1, 0, 0, 0}, st.Distribution)
+}
+
+func TestComputeSkipsCustom(t *testing.T) {
+	results := []Result{
+		{Outcome: Win, Guesses: 2},
+		{Outcome: Forfeit, Guesses: 1, Custom: "K7QX2P"},
+		{Outcome: Win, Guesses: 4, Custom: "ABC234"},
+	}
+	st := Compute(results)
+	assert.Equal(t, 1, st.Played)
+	assert.Equal(t, 1, st.CurrentStreak)
+	assert.Equal(t, []int{0, 1, 0, 0, 0, 0}, st.Distribution)
+}
+
+func TestComputeCountsHinted(t *testing.T) {
+	results := []Result{
+		{Outcome: Win, Guesses: 2, Hinted: true},
+		{Outcome: Win, Guesses: 3},
+		{Outcome: Loss, Guesses: 5, Hinted: true},
+		{Outcome: Win, Guesses: 4, Hinted: true, Practice: true},
+	}
+	st := Compute(results)
+	assert.Equal(t, 3, st.Played)
+	assert.Equal(t, 2, st.Hinted)
+	assert.Contains(t, st.Format(), "Max streak: 2 | Hinted: 2\n")
+}
+
+func TestFormat(t *testing.T) {
+	st := Stats{
+		Played:        10,
+		Wins:          9,
+		CurrentStreak: 4,
+		MaxStreak:     5,
+		Distribution:  []int{0, 1, 4, 2, 2, 0},
+	}
+	expected := strings.Join([]string{
+		"```",
+		"Played: 10 | Win %: 90 | Current streak: 4 | Max streak: 5",
+		"Guess distribution",
+		"1 | 0",
+		"2 | █████ 1",
+		"3 | ████████████████████ 4",
+		"4 | ██████████ 2",
+		"5 | ██████████ 2",
+		"6 | 0",
+		"```",
+	}, "\n")
+	assert.Equal(t, expected, st.Format())
+}
+
+func TestFormatAlignsLongDistribution(t *testing.T) {
+	st := Stats{
+		Played:       1,
+		Wins:         1,
+		Distribution: make([]int, 10),
+	}
+	st.Distribution[9] = 1
+	formatted := st.Format()
+	assert.Contains(t, formatted, "\n 1 | 0\n")
+	assert.Contains(t, formatted, "\n10 | "+strings.Repeat("█", maxBarWidth)+" 1\n")
+}
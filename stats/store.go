@@ -0,0 +1,110 @@
+package stats
+
+import (
+	"sync"
+
+	"github.com/saxypandabear/wordlego/internal/jsonfile"
+)
+
+// Store keeps track of the results of completed games. Implementations must be
+// safe to use from multiple goroutines.
+type Store interface {
+	// Record saves the result of a completed game
+	Record(r Result) error
+	// Results returns all of the results for the given player, in the order they were recorded
+	Results(player string) []Result
+	// All returns all of the results for every player, in the order they were recorded
+	All() []Result
+}
+
+// MemoryStore is a Store that only keeps results in memory. All of the results
+// are lost when the bot restarts.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	results []Result
+}
+
+// NewMemoryStore creates an empty in-memory results store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (m *MemoryStore) Record(r Result) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.results = append(m.results, r)
+	return nil
+}
+
+func (m *MemoryStore) Results(player string) []Result {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return filterPlayer(m.results, player)
+}
+
+func (m *MemoryStore) All() []Result {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Result(nil), m.results...)
+}
+
+// FileStore is a Store that writes all of the results to a JSON file on disk
+// every time a game is recorded, so that the results survive the bot restarting.
+// The results are also kept in memory, so reads never touch the disk.
+type FileStore struct {
+	mu      sync.RWMutex
+	path    string
+	results []Result
+}
+
+// NewFileStore creates a results store that is backed by the JSON file at the
+// given path. If the file already exists, the results in it are loaded into
+// the store. If it does not exist, it is created on the first write.
+func NewFileStore(path string) (*FileStore, error) {
+	fs := FileStore{
+		path: path,
+	}
+	if err := jsonfile.Load(path, &fs.results); err != nil {
+		return nil, err
+	}
+	return &fs, nil
+}
+
+func (f *FileStore) Record(r Result) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results = append(f.results, r)
+	if err := f.save(); err != nil {
+		f.results = f.results[:len(f.results)-1] // keep the memory in sync with the file
+		return err
+	}
+	return nil
+}
+
+func (f *FileStore) Results(player string) []Result {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return filterPlayer(f.results, player)
+}
+
+func (f *FileStore) All() []Result {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return append([]Result(nil), f.results...)
+}
+
+// save writes all of the results to the file. The caller must hold the write lock.
+func (f *FileStore) save() error {
+	return jsonfile.Save(f.path, f.results)
+}
+
+// filterPlayer returns a copy of the results that belong to the given player.
+func filterPlayer(results []Result, player string) []Result {
+	var filtered []Result
+	for _, r := range results {
+		if r.Player == player {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "stats.json"))
+	assert.NoError(t, err)
+	testStore(t, store)
+}
+
+func TestFileStoreSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	store, err := NewFileStore(path)
+	assert.NoError(t, err)
+	r := Result{
+		Player:     "player",
+		Puzzle:     200,
+		Guesses:    4,
+		MaxGuesses: 6,
+		HardMode:   true,
+		Outcome:    Win,
+		Finished:   time.Date(2022, time.February, 1, 12, 0, 0, 0, time.UTC),
+	}
+	assert.NoError(t, store.Record(r))
+
+	restarted, err := NewFileStore(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []Result{r}, restarted.Results("player"))
+}
+
+func TestFileStoreFailedWrite(t *testing.T) {
+	// the parent directory doesn't exist, so the file can't be written
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "missing", "stats.json"))
+	assert.NoError(t, err)
+	assert.Error(t, store.Record(Result{Player: "player"}))
+	assert.Empty(t, store.All())
+}
+
+func testStore(t *testing.T, store Store) {
+	assert.Empty(t, store.Results("player"))
+	assert.Empty(t, store.All())
+
+	assert.NoError(t, store.Record(Result{Player: "player", Puzzle: 1}))
+	assert.NoError(t, store.Record(Result{Player: "other-player", Puzzle: 1}))
+	assert.NoError(t, store.Record(Result{Player: "player", Puzzle: 2}))
+
+	assert.Equal(t, []Result{{Player: "player", Puzzle: 1}, {Player: "player", Puzzle: 2}}, store.Results("player"))
+	assert.Equal(t, []Result{{Player: "other-player", Puzzle: 1}}, store.Results("other-player"))
+	assert.Len(t, store.All(), 3)
+
+	// modifying the returned results shouldn't affect the store
+	store.All()[0].Puzzle = 100
+	store.Results("player")[0].Puzzle = 100
+	assert.Equal(t, 1, store.All()[0].Puzzle)
+}
@@ -5,31 +5,61 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
 
 	"github.com/joho/godotenv"
+	"github.com/saxypandabear/wordlego/config"
 	"github.com/saxypandabear/wordlego/game"
+	"github.com/saxypandabear/wordlego/puzzles"
+	"github.com/saxypandabear/wordlego/schedule"
+	"github.com/saxypandabear/wordlego/solver"
+	"github.com/saxypandabear/wordlego/stats"
+	"github.com/saxypandabear/wordlego/words"
 
 	"github.com/bwmarrin/discordgo"
 )
 
 // Bot parameters
 var (
-	GuildID  string
-	BotToken string
-	AppID    string
+	GuildID      string
+	BotToken     string
+	AppID        string
+	SessionsFile string
+	StatsFile    string
+	ConfigFile   string
+	PuzzlesFile  string
+	WordBankDir  string
+	AnnounceChan string
 )
 
 var s *discordgo.Session
 
+// the lowest value for the max-guesses option
+var minMaxGuesses = float64(1)
+
+// the shortest word length for the length option
+var minLength = float64(words.MinLength)
+
+// the configuration of each guild, which is shared with the game
+var configs config.Store = config.NewMemoryStore()
+
 func init() {
 	err := godotenv.Load()
 	if err != nil {
 		log.Fatal("Error loading .env file")
 	}
 
-	flag.StringVar(&GuildID, "guild", os.Getenv("GUILDID"), "Test guild ID")
+	flag.StringVar(&GuildID, "guild", os.Getenv("GUILDID"), "Test guild ID. Commands are registered globally if empty")
 	flag.StringVar(&BotToken, "token", os.Getenv("TOKEN"), "Bot access token")
 	flag.StringVar(&AppID, "app", os.Getenv("APPID"), "Application ID")
+	flag.StringVar(&SessionsFile, "sessions", os.Getenv("SESSIONSFILE"), "File to persist active game sessions to. Sessions are only kept in memory if empty")
+	flag.StringVar(&StatsFile, "stats", os.Getenv("STATSFILE"), "File to persist the results of completed games to. Results are only kept in memory if empty")
+	flag.StringVar(&ConfigFile, "config", os.Getenv("CONFIGFILE"), "File to persist the configuration of each guild to. The configuration is only kept in memory if empty")
+	flag.StringVar(&PuzzlesFile, "puzzles", os.Getenv("PUZZLESFILE"), "File to persist the custom puzzles that players create to. Puzzles are only kept in memory if empty")
+	flag.StringVar(&WordBankDir, "words", os.Getenv("WORDBANKDIR"), "Directory of word lists to use in place of the built-in lists. The built-in lists are used if empty")
+	flag.StringVar(&AnnounceChan, "announce", os.Getenv("ANNOUNCECHANNEL"), "Channel ID to announce each daily puzzle in for the GUILDID guild, unless the guild configures its own")
+	flag.Parse()
 }
 
 func init() {
@@ -40,9 +70,76 @@ func init() {
 	}
 }
 
+// the word lists are loaded before any of the stores, since restoring a saved session
+// looks up the words for its language and length, see game.WordleSession.UnmarshalJSON
+func init() {
+	if WordBankDir == "" {
+		return
+	}
+	if err := words.LoadWordBanks(WordBankDir); err != nil {
+		log.Fatalf("Cannot load the word lists: %v", err)
+	}
+}
+
+func init() {
+	if SessionsFile == "" {
+		return
+	}
+	store, err := game.NewFileStore(SessionsFile)
+	if err != nil {
+		log.Fatalf("Cannot load the game sessions: %v", err)
+	}
+	game.UseSessionStore(store)
+}
+
+func init() {
+	if StatsFile == "" {
+		return
+	}
+	store, err := stats.NewFileStore(StatsFile)
+	if err != nil {
+		log.Fatalf("Cannot load the game results: %v", err)
+	}
+	game.UseStatsStore(store)
+}
+
+func init() {
+	if PuzzlesFile == "" {
+		return
+	}
+	store, err := puzzles.NewFileStore(PuzzlesFile)
+	if err != nil {
+		log.Fatalf("Cannot load the custom puzzles: %v", err)
+	}
+	game.UsePuzzleStore(store)
+}
+
+func init() {
+	if ConfigFile != "" {
+		store, err := config.NewFileStore(ConfigFile)
+		if err != nil {
+			log.Fatalf("Cannot load the guild configuration: %v", err)
+		}
+		configs = store
+	}
+	game.UseConfigStore(configs)
+}
+
 var (
 	commandsHandlers = map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate){
-		"wordle": game.Wordle,
+		"wordle":       game.Wordle,
+		"wordle-admin": game.Admin,
+	}
+	componentsHandlers = map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate){
+		game.GuessButtonID:         game.GuessButton,
+		game.CoopGuessButtonID:     game.CoopGuessButton,
+		game.MatchPlayButtonID:     game.MatchPlayButton,
+		game.PracticeAgainButtonID: game.PracticeAgainButton,
+		game.AnalyzeButtonID:       game.AnalyzeButton,
+	}
+	modalsHandlers = map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate){
+		game.GuessModalID:     game.GuessModal,
+		game.CoopGuessModalID: game.CoopGuessModal,
 	}
 )
 
@@ -50,12 +147,23 @@ func main() {
 	s.AddHandler(func(s *discordgo.Session, r *discordgo.Ready) {
 		log.Println("Bot is up!")
 	})
+	// building the solver takes a while, so get it ready before anyone analyzes a game
+	go solver.Default()
 	s.AddHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 		switch i.Type {
 		case discordgo.InteractionApplicationCommand:
 			if h, ok := commandsHandlers[i.ApplicationCommandData().Name]; ok {
 				h(s, i)
 			}
+		case discordgo.InteractionMessageComponent:
+			// components can carry data after a colon in their custom ID, see game.PracticeAgainButtonID
+			if h, ok := componentsHandlers[strings.SplitN(i.MessageComponentData().CustomID, ":", 2)[0]]; ok {
+				h(s, i)
+			}
+		case discordgo.InteractionModalSubmit:
+			if h, ok := modalsHandlers[i.ModalSubmitData().CustomID]; ok {
+				h(s, i)
+			}
 		}
 	})
 
@@ -63,14 +171,18 @@ func main() {
 	// This command is a single entrypoint for the Wordle game.
 	// It accepts different subcommand options (via a choice of set values),
 	// along with all of the necessary subcommand options for each of those actions.
+	// The command can be used in direct messages too, but Discord only shows commands
+	// in direct messages when they are registered globally, i.e. without a guild ID.
+	dmPermission := true
 	_, err := s.ApplicationCommandCreate(AppID, GuildID, &discordgo.ApplicationCommand{
-		Name:        "wordle",
-		Description: "Play Wordle! This initiates a new game for the player.",
-		Type:        discordgo.ChatApplicationCommand,
+		Name:         "wordle",
+		Description:  "Play Wordle! This initiates a new game for the player.",
+		Type:         discordgo.ChatApplicationCommand,
+		DMPermission: &dmPermission,
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "action",
+				Name:        game.ActionOption,
 				Description: "Action to invoke",
 				Required:    true,
 				Choices: []*discordgo.ApplicationCommandOptionChoice{
@@ -86,32 +198,307 @@ func main() {
 						Name:  "guess",
 						Value: game.Guess,
 					},
+					{
+						Name:  "hint",
+						Value: game.Hint,
+					},
 					{
 						Name:  "help",
 						Value: game.Help,
 					},
+					{
+						Name:  "stats",
+						Value: game.Stats,
+					},
+					{
+						Name:  "leaderboard",
+						Value: game.Leaderboard,
+					},
+					{
+						Name:  "challenge",
+						Value: game.Challenge,
+					},
+					{
+						Name:  "create",
+						Value: game.Create,
+					},
 				},
 			},
 			{
 				Type:         discordgo.ApplicationCommandOptionString,
-				Name:         "word",
+				Name:         game.WordOption,
 				Description:  "Word to guess",
 				Required:     false,
 				Autocomplete: true,
 			},
 			{
 				Type:         discordgo.ApplicationCommandOptionInteger,
-				Name:         "puzzle-num",
+				Name:         game.PuzzleNumOption,
 				Description:  "Specific puzzle to try to solve. Defaults to the current day",
 				Required:     false,
 				Autocomplete: true,
 			},
 			{
 				Type:         discordgo.ApplicationCommandOptionInteger,
-				Name:         "max-guesses",
+				Name:         game.MaxGuessesOption,
 				Description:  "Configure the maximum number of guesses for the puzzle",
 				Required:     false,
 				Autocomplete: true,
+				MinValue:     &minMaxGuesses,
+				MaxValue:     config.MaxMaxGuesses,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        game.KeyboardOption,
+				Description: "Keyboard layout to display the used letters with. Defaults to the language's layout",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "QWERTY",
+						Value: game.QWERTY.Name,
+					},
+					{
+						Name:  "AZERTY",
+						Value: game.AZERTY.Name,
+					},
+					{
+						Name:  "QWERTZ",
+						Value: game.QWERTZ.Name,
+					},
+					{
+						Name:  "QWERTY (Spanish)",
+						Value: game.SpanishQWERTY.Name,
+					},
+					{
+						Name:  "QWERTZ (German)",
+						Value: game.GermanQWERTZ.Name,
+					},
+					{
+						Name:  "QWERTY (Portuguese)",
+						Value: game.PortugueseQWERTY.Name,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        game.HardModeOption,
+				Description: "Any revealed hints must be used in subsequent guesses",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        game.LengthOption,
+				Description: "Number of letters in the word, from 4 to 8. Defaults to 5",
+				Required:    false,
+				MinValue:    &minLength,
+				MaxValue:    words.MaxLength,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        game.LanguageOption,
+				Description: "Language of the word. Defaults to the server's language",
+				Required:    false,
+				Choices:     languageChoices(),
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        game.ModeOption,
+				Description: "Play solo, with the channel, with a random practice puzzle, or against the bot. Defaults to solo",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "solo",
+						Value: game.Solo,
+					},
+					{
+						Name:  "coop",
+						Value: game.Coop,
+					},
+					{
+						Name:  "practice",
+						Value: game.Practice,
+					},
+					{
+						Name:  "absurdle",
+						Value: game.Absurdle,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        game.TakeTurnsOption,
+				Description: "In coop mode, nobody can guess twice in a row. Defaults to the server's configuration",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        game.CodeOption,
+				Description: "Code of a custom puzzle to play",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        game.BoardsOption,
+				Description: "Number of boards to solve at once with the same guesses. Defaults to 1",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "1 (Wordle)",
+						Value: 1,
+					},
+					{
+						Name:  "2 (Dordle)",
+						Value: 2,
+					},
+					{
+						Name:  "4 (Quordle)",
+						Value: 4,
+					},
+					{
+						Name:  "8 (Octordle)",
+						Value: 8,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        game.HintTypeOption,
+				Description: "What a hint reveals, for a guess. Defaults to a letter",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "a letter",
+						Value: game.LetterHint,
+					},
+					{
+						Name:  "how many words are left",
+						Value: game.CountHint,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        game.OpponentOption,
+				Description: "Member to race against",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        game.WindowOption,
+				Description: "Range of daily puzzles for the leaderboard. Defaults to today",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{
+						Name:  "today",
+						Value: stats.Today,
+					},
+					{
+						Name:  "week",
+						Value: stats.Week,
+					},
+					{
+						Name:  "month",
+						Value: stats.Month,
+					},
+					{
+						Name:  "all time",
+						Value: stats.AllTime,
+					},
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		log.Fatalf("Cannot create slash command: %v", err)
+	}
+
+	// Wordle admin command registration
+	// This configures how Wordle is played in a guild, so it's hidden from members without
+	// the Manage Server permission by default, and can't be used in direct messages.
+	noDMs := false
+	manageServer := int64(discordgo.PermissionManageServer)
+	_, err = s.ApplicationCommandCreate(AppID, GuildID, &discordgo.ApplicationCommand{
+		Name:                     "wordle-admin",
+		Description:              "Configure Wordle for this server",
+		Type:                     discordgo.ChatApplicationCommand,
+		DMPermission:             &noDMs,
+		DefaultMemberPermissions: &manageServer,
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        game.ConfigSubcommand,
+				Description: "Show or change the configuration. Only the options provided are changed",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        game.MaxGuessesOption,
+						Description: "Default maximum number of guesses for new games",
+						Required:    false,
+						MinValue:    &minMaxGuesses,
+						MaxValue:    config.MaxMaxGuesses,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        game.HardModeOption,
+						Description: "Whether new games default to hard mode",
+						Required:    false,
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionChannel,
+						Name:         game.AnnounceChannelOption,
+						Description:  "Channel to announce each daily puzzle in",
+						Required:     false,
+						ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        game.AnnounceOption,
+						Description: "Set to false to stop announcing the daily puzzle",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        game.SpoilersOption,
+						Description: "Who gets to see the result of a finished game",
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{
+								Name:  "everyone in the channel",
+								Value: config.Public,
+							},
+							{
+								Name:  "only the player",
+								Value: config.Private,
+							},
+						},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        game.MinPuzzleOption,
+						Description: "Lowest puzzle number that can be replayed. 0 for no limit",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Name:        game.MaxPuzzleOption,
+						Description: "Highest puzzle number that can be replayed. 0 for no limit",
+						Required:    false,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        game.LanguageOption,
+						Description: "Language that games default to",
+						Required:    false,
+						Choices:     languageChoices(),
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        game.TakeTurnsOption,
+						Description: "Whether coop games default to nobody guessing twice in a row",
+						Required:    false,
+					},
+				},
 			},
 		},
 	})
@@ -126,8 +513,56 @@ func main() {
 	}
 	defer s.Close()
 
+	// Daily puzzle announcements
+	// At every UTC midnight, this recaps the puzzle that just ended, and announces the new one
+	// in every guild that has an announcement channel.
+	stopScheduler := make(chan struct{})
+	defer close(stopScheduler)
+	post := func(channel, content string) error {
+		_, err := s.ChannelMessageSendComplex(channel, &discordgo.MessageSend{
+			Content:         content,
+			AllowedMentions: &discordgo.MessageAllowedMentions{}, // don't ping everyone in the recap
+		})
+		return err
+	}
+	go schedule.New(schedule.RealClock, announceTargets, post, game.Recap).Run(stopScheduler)
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt)
 	<-stop
 	log.Println("Graceful shutdown")
 }
+
+// announceTargets returns the channels to announce the daily puzzle in. The guild
+// configuration takes precedence over the ANNOUNCECHANNEL fallback for the test guild.
+func announceTargets() []schedule.Target {
+	var targets []schedule.Target
+	guilds := configs.List()
+	for guild, c := range guilds {
+		if c.AnnounceChannel != "" {
+			targets = append(targets, schedule.Target{Guild: guild, Channel: c.AnnounceChannel})
+		}
+	}
+	if _, configured := guilds[GuildID]; AnnounceChan != "" && !configured {
+		targets = append(targets, schedule.Target{Guild: GuildID, Channel: AnnounceChan})
+	}
+	return targets
+}
+
+// languageChoices returns a choice for each language that puzzles can be played in,
+// sorted by code so the choices don't move around between restarts.
+func languageChoices() []*discordgo.ApplicationCommandOptionChoice {
+	codes := make([]string, 0, len(words.Languages))
+	for code := range words.Languages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	choices := make([]*discordgo.ApplicationCommandOptionChoice, len(codes))
+	for i, code := range codes {
+		choices[i] = &discordgo.ApplicationCommandOptionChoice{
+			Name:  words.Languages[code].Name,
+			Value: code,
+		}
+	}
+	return choices
+}
@@ -0,0 +1,221 @@
+package words
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+// WordBank is a source of puzzle solutions and allowed guesses, for words of a
+// single length in a single language. Implementations must be safe to use from
+// multiple goroutines.
+type WordBank interface {
+	// Language is the code of the language that the words are in, see Languages
+	Language() string
+	// Length is the number of letters in every word in the bank
+	Length() int
+	// Solutions returns the solutions in puzzle order. index 0 = puzzle 1
+	Solutions() []string
+	// IsAllowed returns whether the word can be guessed, which includes all of the solutions
+	IsAllowed(word string) bool
+	// Allowed returns every word that can be guessed, including the solutions, sorted
+	Allowed() []string
+}
+
+// ListBank is a WordBank that is backed by lists of words.
+type ListBank struct {
+	language  string
+	length    int
+	solutions []string
+	sorted    []string // the solutions and the allowed words together, sorted for searching
+}
+
+// NewListBank creates an English word bank from the solutions, in puzzle order, and
+// the words that are allowed as guesses on top of the solutions. See NewLanguageBank.
+func NewListBank(solutions, allowed []string) (*ListBank, error) {
+	return NewLanguageBank(English, solutions, allowed)
+}
+
+// NewLanguageBank creates a word bank for the language from the solutions, in puzzle
+// order, and the words that are allowed as guesses on top of the solutions. The words
+// are validated, so this returns an error if the words aren't all the same supported
+// length, if any of them have characters that aren't lowercase letters in the language's
+// alphabet, or if any of the solutions are listed more than once. Allowed guesses that
+// are already solutions, or listed more than once, are only kept once, so that a whole
+// dictionary can be used as the allowed guesses.
+// A bank without any solutions is only useful for adding allowed guesses to
+// another bank, see Extend.
+func NewLanguageBank(lang Language, solutions, allowed []string) (*ListBank, error) {
+	all := make([]string, 0, len(solutions)+len(allowed))
+	all = append(all, solutions...)
+	all = append(all, allowed...)
+	if len(all) == 0 {
+		return nil, fmt.Errorf("a word bank needs at least one word")
+	}
+
+	length := utf8.RuneCountInString(all[0])
+	if !IsValidLength(length) {
+		return nil, fmt.Errorf("words must be between %d and %d letters long, but '%s' has %d", MinLength, MaxLength, all[0], length)
+	}
+	seen := make(map[string]bool, len(all))
+	unique := all[:0]
+	for i, w := range all {
+		if n := utf8.RuneCountInString(w); n != length {
+			return nil, fmt.Errorf("'%s' has %d letters, but the other words have %d", w, n, length)
+		}
+		for _, c := range w {
+			if !lang.HasLetter(c) {
+				return nil, fmt.Errorf("'%s' has a letter that isn't in the %s alphabet", w, lang.Name)
+			}
+		}
+		if seen[w] && i < len(solutions) {
+			return nil, fmt.Errorf("'%s' is listed more than once", w)
+		}
+		if !seen[w] {
+			unique = append(unique, w)
+		}
+		seen[w] = true
+	}
+
+	sort.Strings(unique)
+	return &ListBank{
+		language:  lang.Code,
+		length:    length,
+		solutions: append([]string(nil), solutions...),
+		sorted:    unique,
+	}, nil
+}
+
+func (b *ListBank) Language() string {
+	return b.language
+}
+
+func (b *ListBank) Length() int {
+	return b.length
+}
+
+func (b *ListBank) Solutions() []string {
+	return b.solutions
+}
+
+func (b *ListBank) IsAllowed(word string) bool {
+	idx := sort.SearchStrings(b.sorted, word)
+	return idx < len(b.sorted) && b.sorted[idx] == word
+}
+
+func (b *ListBank) Allowed() []string {
+	return b.sorted
+}
+
+// extendedBank is a WordBank that allows the guesses from another bank on top of its own.
+type extendedBank struct {
+	WordBank
+	extra WordBank
+}
+
+func (b extendedBank) IsAllowed(word string) bool {
+	return b.WordBank.IsAllowed(word) || b.extra.IsAllowed(word)
+}
+
+// Allowed merges the allowed words of both banks, which are each sorted already.
+func (b extendedBank) Allowed() []string {
+	base, extra := b.WordBank.Allowed(), b.extra.Allowed()
+	merged := make([]string, 0, len(base)+len(extra))
+	for len(base) > 0 || len(extra) > 0 {
+		switch {
+		case len(extra) == 0 || (len(base) > 0 && base[0] < extra[0]):
+			merged, base = append(merged, base[0]), base[1:]
+		case len(base) == 0 || extra[0] < base[0]:
+			merged, extra = append(merged, extra[0]), extra[1:]
+		default: // the same word is in both
+			merged, base, extra = append(merged, base[0]), base[1:], extra[1:]
+		}
+	}
+	return merged
+}
+
+// Extend returns a bank with the solutions of the base bank, that also allows all
+// of the words in the extra bank to be guessed.
+func Extend(base, extra WordBank) (WordBank, error) {
+	if base.Language() != extra.Language() {
+		return nil, fmt.Errorf("can't extend a bank of words in '%s' with words in '%s'", base.Language(), extra.Language())
+	}
+	if base.Length() != extra.Length() {
+		return nil, fmt.Errorf("can't extend a bank of %d letter words with %d letter words", base.Length(), extra.Length())
+	}
+	return extendedBank{WordBank: base, extra: extra}, nil
+}
+
+// the word banks that puzzles are played with, keyed by language and then word length.
+// these start out as the built-in lists of words, see UseWordBank to replace them.
+var (
+	banksMu sync.RWMutex
+	banks   = builtinBanks()
+)
+
+// builtinBanks creates the word banks from the lists of words that are compiled in.
+// There are English words of every supported length, and five letter words in the
+// other languages.
+func builtinBanks() map[string]map[int]WordBank {
+	builtin := map[string]map[int]WordBank{
+		English.Code: make(map[int]WordBank, len(solutionsByLength)),
+	}
+	for length, sols := range solutionsByLength {
+		builtin[English.Code][length] = mustBank(English, sols, allowedByLength[length])
+	}
+	builtin[Spanish.Code] = map[int]WordBank{DefaultLength: mustBank(Spanish, SpanishSolutions, SpanishAllowedWords)}
+	builtin[German.Code] = map[int]WordBank{DefaultLength: mustBank(German, GermanSolutions, GermanAllowedWords)}
+	builtin[Portuguese.Code] = map[int]WordBank{DefaultLength: mustBank(Portuguese, PortugueseSolutions, PortugueseAllowedWords)}
+	return builtin
+}
+
+// mustBank creates one of the built-in banks, which are covered by the tests.
+func mustBank(lang Language, solutions, allowed []string) WordBank {
+	bank, err := NewLanguageBank(lang, solutions, allowed)
+	if err != nil {
+		panic(fmt.Sprintf("the built-in %s word bank is invalid: %s", lang.Name, err))
+	}
+	return bank
+}
+
+// UseWordBank replaces the word bank for puzzles in the bank's language and of the
+// bank's length. A bank that doesn't have any solutions extends the current bank
+// instead, so that it allows more guesses without changing the puzzles.
+// This should be called before the bot starts handling interactions, since changing
+// the solutions changes the puzzles that are in progress.
+func UseWordBank(bank WordBank) error {
+	if _, err := GetLanguage(bank.Language()); err != nil {
+		return err
+	}
+	if !IsValidLength(bank.Length()) {
+		return fmt.Errorf("words must be between %d and %d letters long, not %d", MinLength, MaxLength, bank.Length())
+	}
+	banksMu.Lock()
+	defer banksMu.Unlock()
+	if banks[bank.Language()] == nil {
+		banks[bank.Language()] = make(map[int]WordBank)
+	}
+	if len(bank.Solutions()) == 0 {
+		base, ok := banks[bank.Language()][bank.Length()]
+		if !ok {
+			return fmt.Errorf("there are no %d letter words in '%s' to extend", bank.Length(), bank.Language())
+		}
+		extended, err := Extend(base, bank)
+		if err != nil {
+			return err
+		}
+		bank = extended
+	}
+	banks[bank.Language()][bank.Length()] = bank
+	return nil
+}
+
+// GetWordBank returns the word bank for puzzles in the language with words of the
+// given length, and whether there is one.
+func GetWordBank(language string, length int) (WordBank, bool) {
+	banksMu.RLock()
+	defer banksMu.RUnlock()
+	bank, ok := banks[language][length]
+	return bank, ok
+}
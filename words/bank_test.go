@@ -0,0 +1,129 @@
+package words
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// useBuiltinBanks restores the built-in word banks once the test finishes.
+func useBuiltinBanks(t *testing.T) {
+	t.Cleanup(func() {
+		banksMu.Lock()
+		defer banksMu.Unlock()
+		banks = builtinBanks()
+	})
+}
+
+func TestNewListBank(t *testing.T) {
+	bank, err := NewListBank([]string{"cigar", "rebut"}, []string{"aahed"})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, bank.Length())
+	assert.Equal(t, []string{"cigar", "rebut"}, bank.Solutions())
+	assert.True(t, bank.IsAllowed("cigar"))
+	assert.True(t, bank.IsAllowed("aahed"))
+	assert.False(t, bank.IsAllowed("hello"))
+	assert.Equal(t, []string{"aahed", "cigar", "rebut"}, bank.Allowed())
+
+	bank, err = NewListBank(nil, []string{"aahed"})
+	assert.NoError(t, err)
+	assert.Empty(t, bank.Solutions())
+}
+
+func TestNewListBankInvalid(t *testing.T) {
+	_, err := NewListBank(nil, nil)
+	assert.EqualError(t, err, "a word bank needs at least one word")
+	_, err = NewListBank([]string{"cigar", "rebuts"}, nil)
+	assert.EqualError(t, err, "'rebuts' has 6 letters, but the other words have 5")
+	_, err = NewListBank([]string{"cigar"}, []string{"piñas"})
+	assert.EqualError(t, err, "'piñas' has a letter that isn't in the English alphabet")
+	_, err = NewListBank([]string{"cigar"}, []string{"Rebut"})
+	assert.EqualError(t, err, "'Rebut' has a letter that isn't in the English alphabet")
+	_, err = NewListBank([]string{"cigar", "rebut", "cigar"}, nil)
+	assert.EqualError(t, err, "'cigar' is listed more than once")
+
+	// allowed guesses that repeat a solution or each other are only kept once
+	bank, err := NewListBank([]string{"cigar", "rebut"}, []string{"cigar", "aahed", "aahed"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"aahed", "cigar", "rebut"}, bank.sorted)
+	assert.Equal(t, []string{"cigar", "rebut"}, bank.Solutions())
+	_, err = NewListBank([]string{"abc"}, nil)
+	assert.EqualError(t, err, "words must be between 4 and 8 letters long, but 'abc' has 3")
+}
+
+func TestUseWordBank(t *testing.T) {
+	useBuiltinBanks(t)
+	bank, _ := NewListBank([]string{"garden", "banana"}, []string{"zzzzzz"})
+	assert.NoError(t, UseWordBank(bank))
+
+	actual, err := GetSolution(DefaultLanguage, 6, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "banana", actual)
+	assert.True(t, IsGuessValid("zzzzzz"))
+	assert.False(t, IsGuessValid("absent")) // only in the built-in bank
+	// the other lengths are unaffected
+	assert.True(t, IsGuessValid("hello"))
+}
+
+func TestUseWordBankExtends(t *testing.T) {
+	useBuiltinBanks(t)
+	extra, _ := NewListBank(nil, []string{"zzzzz"})
+	assert.NoError(t, UseWordBank(extra))
+
+	assert.True(t, IsGuessValid("zzzzz"))
+	assert.True(t, IsGuessValid("hello"))
+	actual, err := GetSpecificWordleSolution(1)
+	assert.NoError(t, err)
+	assert.Equal(t, Solutions[0], actual)
+}
+
+func TestExtendAllowed(t *testing.T) {
+	base, _ := NewListBank([]string{"cigar", "rebut"}, []string{"aahed"})
+	extra, _ := NewListBank(nil, []string{"zzzzz", "cigar", "abbey"})
+	bank, err := Extend(base, extra)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"aahed", "abbey", "cigar", "rebut", "zzzzz"}, bank.Allowed())
+	assert.Equal(t, []string{"cigar", "rebut"}, bank.Solutions())
+}
+
+func TestNewLanguageBank(t *testing.T) {
+	bank, err := NewLanguageBank(Spanish, []string{"señal", "arbol"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Spanish.Code, bank.Language())
+	assert.Equal(t, 5, bank.Length())
+	assert.True(t, bank.IsAllowed("señal"))
+
+	_, err = NewLanguageBank(German, []string{"schön", "straße"}, nil)
+	assert.EqualError(t, err, "'straße' has 6 letters, but the other words have 5")
+	_, err = NewLanguageBank(German, []string{"schön", "grüße"}, nil)
+	assert.EqualError(t, err, "'grüße' has a letter that isn't in the Deutsch alphabet")
+}
+
+func TestUseWordBankLanguages(t *testing.T) {
+	useBuiltinBanks(t)
+	bank, _ := NewLanguageBank(German, []string{"schön"}, nil)
+	assert.NoError(t, UseWordBank(bank))
+	actual, err := GetSolution(German.Code, 5, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "schön", actual)
+	// the other languages are unaffected
+	assert.True(t, IsGuessValidIn(Spanish.Code, SpanishSolutions[0]))
+	assert.True(t, IsGuessValid("hello"))
+
+	extra, _ := NewLanguageBank(Spanish, nil, []string{"abcdef"})
+	assert.EqualError(t, UseWordBank(extra), "there are no 6 letter words in 'es' to extend")
+}
+
+func TestExtendDifferentLanguages(t *testing.T) {
+	base, _ := NewListBank([]string{"cigar"}, nil)
+	extra, _ := NewLanguageBank(Spanish, nil, []string{"señal"})
+	_, err := Extend(base, extra)
+	assert.EqualError(t, err, "can't extend a bank of words in 'en' with words in 'es'")
+}
+
+func TestExtendDifferentLengths(t *testing.T) {
+	base, _ := NewListBank([]string{"cigar"}, nil)
+	extra, _ := NewListBank(nil, []string{"garden"})
+	_, err := Extend(base, extra)
+	assert.EqualError(t, err, "can't extend a bank of 5 letter words with 6 letter words")
+}
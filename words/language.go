@@ -0,0 +1,68 @@
+package words
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Language is a language that puzzles can be played in, with its own word banks.
+type Language struct {
+	Code     string // the ISO 639-1 code that the language is picked by
+	Name     string // the name of the language, in the language itself
+	Alphabet string // all of the letters that the words can use
+}
+
+var (
+	// English is the language of the original Wordle. This is the default language.
+	English = Language{
+		Code:     "en",
+		Name:     "English",
+		Alphabet: "abcdefghijklmnopqrstuvwxyz",
+	}
+	// Spanish words are written without accents, except for ñ
+	Spanish = Language{
+		Code:     "es",
+		Name:     "Español",
+		Alphabet: "abcdefghijklmnñopqrstuvwxyz",
+	}
+	// German words are written with umlauts, and without ß
+	German = Language{
+		Code:     "de",
+		Name:     "Deutsch",
+		Alphabet: "abcdefghijklmnopqrstuvwxyzäöü",
+	}
+	// Portuguese words are written without accents, except for ç
+	Portuguese = Language{
+		Code:     "pt",
+		Name:     "Português",
+		Alphabet: "abcdefghijklmnopqrstuvwxyzç",
+	}
+)
+
+// DefaultLanguage is the code of the language that is played when none is picked
+const DefaultLanguage = "en"
+
+// Languages are all of the languages that puzzles can be played in, keyed by code.
+var Languages = map[string]Language{
+	English.Code:    English,
+	Spanish.Code:    Spanish,
+	German.Code:     German,
+	Portuguese.Code: Portuguese,
+}
+
+// GetLanguage looks up the language with the given code. An empty code is the
+// default language.
+func GetLanguage(code string) (Language, error) {
+	if code == "" {
+		code = DefaultLanguage
+	}
+	if lang, ok := Languages[code]; ok {
+		return lang, nil
+	}
+	return Language{}, fmt.Errorf("'%s' is not a supported language", code)
+}
+
+// HasLetter returns whether the letter is in the language's alphabet.
+func (l Language) HasLetter(c rune) bool {
+	return strings.ContainsRune(l.Alphabet, c)
+}
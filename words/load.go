@@ -0,0 +1,189 @@
+package words
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// the suffixes of the files that LoadWordBanks reads
+const (
+	jsonSuffix      = ".json"
+	solutionsSuffix = ".solutions.txt"
+	allowedSuffix   = ".allowed.txt"
+)
+
+// jsonBank is the format of a word bank in a JSON file
+type jsonBank struct {
+	Solutions []string `json:"solutions"`
+	Allowed   []string `json:"allowed"`
+}
+
+// LoadJSONBank reads a word bank for the language from a JSON file that has a list
+// of "solutions", in puzzle order, and a list of "allowed" guesses, i.e.
+// {"solutions": ["cigar", "rebut"], "allowed": ["aahed", "aalii"]}
+// Either list can be left out. See NewLanguageBank for how the words are validated.
+func LoadJSONBank(lang Language, path string) (*ListBank, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var jb jsonBank
+	if err = json.Unmarshal(data, &jb); err != nil {
+		return nil, err
+	}
+	return NewLanguageBank(lang, normalize(jb.Solutions), normalize(jb.Allowed))
+}
+
+// LoadTextBank reads a word bank for the language from newline-delimited files, one
+// for the solutions, in puzzle order, and one for the allowed guesses. Either path can
+// be empty to leave that list out. See NewLanguageBank for how the words are validated.
+func LoadTextBank(lang Language, solutionsPath, allowedPath string) (*ListBank, error) {
+	solutions, err := readTextFile(solutionsPath)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := readTextFile(allowedPath)
+	if err != nil {
+		return nil, err
+	}
+	return NewLanguageBank(lang, solutions, allowed)
+}
+
+// LoadWordBanks reads all of the word banks in the directory, and uses them in place
+// of the built-in lists of words. The banks in the directory itself are English, and
+// the banks for other languages go in a subdirectory that is named by the language's
+// code, i.e. es/ for Spanish. Each directory can have:
+// - JSON banks, named *.json. See LoadJSONBank
+// - text banks, named NAME.solutions.txt, and optionally NAME.allowed.txt for the allowed
+// guesses that go with them. See LoadTextBank
+// - a NAME.allowed.txt file on its own, which only adds allowed guesses
+// Banks with solutions replace the bank for their language and length, so there can
+// only be one of them per language and length. Banks without solutions extend the bank
+// for their language and length, see UseWordBank. Nothing is changed if any of the
+// banks are invalid.
+func LoadWordBanks(dir string) error {
+	loaded, err := loadDir(dir, English)
+	if err != nil {
+		return err
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		lang, err := GetLanguage(entry.Name())
+		if err != nil {
+			return fmt.Errorf("%s: %w", filepath.Join(dir, entry.Name()), err)
+		}
+		banks, err := loadDir(filepath.Join(dir, entry.Name()), lang)
+		if err != nil {
+			return err
+		}
+		loaded = append(loaded, banks...)
+	}
+
+	// replace the banks before extending them, so the extensions apply to the new banks
+	sort.SliceStable(loaded, func(i, j int) bool {
+		return len(loaded[i].Solutions()) > 0 && len(loaded[j].Solutions()) == 0
+	})
+	replaced := make(map[string]bool)
+	for _, bank := range loaded {
+		if len(bank.Solutions()) == 0 {
+			continue
+		}
+		key := fmt.Sprintf("%s/%d", bank.Language(), bank.Length())
+		if replaced[key] {
+			return fmt.Errorf("more than one word bank in %s has %d letter solutions in '%s'", dir, bank.Length(), bank.Language())
+		}
+		replaced[key] = true
+	}
+	for _, bank := range loaded {
+		if err = UseWordBank(bank); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadDir reads the word banks in the directory for the language, without going
+// into any subdirectories.
+func loadDir(dir string, lang Language) ([]*ListBank, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var loaded []*ListBank
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(dir, name)
+		var bank *ListBank
+		switch {
+		case entry.IsDir():
+			continue
+		case strings.HasSuffix(name, jsonSuffix):
+			bank, err = LoadJSONBank(lang, path)
+		case strings.HasSuffix(name, solutionsSuffix):
+			allowed := strings.TrimSuffix(path, solutionsSuffix) + allowedSuffix
+			if _, statErr := os.Stat(allowed); statErr != nil {
+				allowed = ""
+			}
+			bank, err = LoadTextBank(lang, path, allowed)
+		case strings.HasSuffix(name, allowedSuffix):
+			if _, statErr := os.Stat(strings.TrimSuffix(path, allowedSuffix) + solutionsSuffix); statErr == nil {
+				continue // loaded along with the solutions
+			}
+			bank, err = LoadTextBank(lang, "", path)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		loaded = append(loaded, bank)
+	}
+	return loaded, nil
+}
+
+// readTextFile reads the newline-delimited words in the file. An empty path has no words.
+func readTextFile(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readLines(f)
+}
+
+// readLines reads one word per line, skipping blank lines.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, strings.ToLower(line))
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// normalize lowercases the words, and trims any surrounding whitespace.
+func normalize(words []string) []string {
+	normalized := make([]string, len(words))
+	for i, w := range words {
+		normalized[i] = strings.ToLower(strings.TrimSpace(w))
+	}
+	return normalized
+}
@@ -0,0 +1,113 @@
+package words
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadJSONBank(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "six.json", `{"solutions": ["Garden", " banana "], "allowed": ["zzzzzz"]}`)
+	bank, err := LoadJSONBank(English, path)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, bank.Length())
+	assert.Equal(t, []string{"garden", "banana"}, bank.Solutions())
+	assert.True(t, bank.IsAllowed("zzzzzz"))
+
+	path = writeFile(t, dir, "invalid.json", `{"solutions": ["garden", "garden"]}`)
+	_, err = LoadJSONBank(English, path)
+	assert.EqualError(t, err, "'garden' is listed more than once")
+
+	path = writeFile(t, dir, "corrupt.json", `{"solutions": [`)
+	_, err = LoadJSONBank(English, path)
+	assert.Error(t, err)
+
+	_, err = LoadJSONBank(English, filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestLoadTextBank(t *testing.T) {
+	dir := t.TempDir()
+	solutions := writeFile(t, dir, "six.solutions.txt", "garden\n\nBANANA\r\n")
+	allowed := writeFile(t, dir, "six.allowed.txt", "zzzzzz\n")
+	bank, err := LoadTextBank(English, solutions, allowed)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"garden", "banana"}, bank.Solutions())
+	assert.True(t, bank.IsAllowed("zzzzzz"))
+
+	bank, err = LoadTextBank(English, "", allowed)
+	assert.NoError(t, err)
+	assert.Empty(t, bank.Solutions())
+
+	_, err = LoadTextBank(English, filepath.Join(dir, "missing.txt"), "")
+	assert.Error(t, err)
+}
+
+func TestLoadWordBanks(t *testing.T) {
+	useBuiltinBanks(t)
+	dir := t.TempDir()
+	writeFile(t, dir, "six.solutions.txt", "garden\nbanana\n")
+	writeFile(t, dir, "six.allowed.txt", "yyyyyy\n")
+	writeFile(t, dir, "more-six.allowed.txt", "zzzzzz\n") // extends the new six letter bank
+	writeFile(t, dir, "seven.json", `{"solutions": ["village"]}`)
+	writeFile(t, dir, "README.md", "not a word bank")
+
+	assert.NoError(t, LoadWordBanks(dir))
+	actual, err := GetSolution(DefaultLanguage, 6, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "garden", actual)
+	assert.True(t, IsGuessValid("yyyyyy"))
+	assert.True(t, IsGuessValid("zzzzzz"))
+	assert.False(t, IsGuessValid("absent"))
+	actual, err = GetSolution(DefaultLanguage, 7, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "village", actual)
+	assert.True(t, IsGuessValid("hello"))
+}
+
+func TestLoadWordBanksLanguages(t *testing.T) {
+	useBuiltinBanks(t)
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "de"), 0755))
+	writeFile(t, dir, "de/five.solutions.txt", "SCHÖN\nküche\n")
+	writeFile(t, dir, "more.allowed.txt", "zzzzz\n")
+
+	assert.NoError(t, LoadWordBanks(dir))
+	actual, err := GetSolution(German.Code, 5, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "schön", actual)
+	assert.False(t, IsGuessValidIn(German.Code, "zzzzz"))
+	assert.True(t, IsGuessValid("zzzzz"))
+
+	dir = t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "xx"), 0755))
+	assert.EqualError(t, LoadWordBanks(dir), filepath.Join(dir, "xx")+": 'xx' is not a supported language")
+}
+
+func TestLoadWordBanksInvalid(t *testing.T) {
+	useBuiltinBanks(t)
+	dir := t.TempDir()
+	writeFile(t, dir, "a.solutions.txt", "garden\n")
+	writeFile(t, dir, "b.json", `{"solutions": ["banana"]}`)
+	assert.EqualError(t, LoadWordBanks(dir), "more than one word bank in "+dir+" has 6 letter solutions in 'en'")
+
+	dir = t.TempDir()
+	writeFile(t, dir, "a.solutions.txt", "garden\n")
+	path := writeFile(t, dir, "b.allowed.txt", "piñata\n")
+	assert.EqualError(t, LoadWordBanks(dir), path+": 'piñata' has a letter that isn't in the English alphabet")
+	// nothing changes when a bank is invalid
+	actual, _ := GetSolution(DefaultLanguage, 6, 1)
+	assert.Equal(t, SixLetterSolutions[0], actual)
+
+	assert.Error(t, LoadWordBanks(filepath.Join(dir, "missing")))
+}
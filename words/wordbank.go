@@ -1,7 +1,5 @@
 package words
 
-import "sort"
-
 var (
 	// in-order solutions. index 0 = Wordle 1
 	Solutions = []string{
@@ -237,8 +235,7 @@ var (
 		"sleek", "riser", "twixt", "peace", "flush", "catty", "login", "eject", "roger", "rival",
 		"untie", "refit", "aorta", "adult", "judge", "rower", "artsy", "rural", "shave",
 	}
-	SortedSolutions []string = nil
-	AllowedWords             = []string{
+	AllowedWords = []string{
 		"aahed", "aalii", "aargh", "aarti", "abaca", "abaci", "abacs", "abaft", "abaka", "abamp",
 		"aband", "abash", "abask", "abaya", "abbas", "abbed", "abbes", "abcee", "abeam", "abear",
 		"abele", "abers", "abets", "abies", "abler", "ables", "ablet", "ablow", "abmho", "abohm",
@@ -1305,12 +1302,3 @@ var (
 		"zupas", "zuppa", "zurfs", "zuzim", "zygal", "zygon", "zymes", "zymic",
 	}
 )
-
-func GetSortedSolutions() []string {
-	if SortedSolutions == nil {
-		SortedSolutions = make([]string, len(Solutions))
-		copy(SortedSolutions, Solutions)
-		sort.Strings(SortedSolutions)
-	}
-	return SortedSolutions
-}
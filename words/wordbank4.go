@@ -0,0 +1,110 @@
+package words
+
+var (
+	// in-order solutions for 4 letter puzzles. index 0 = puzzle 1
+	FourLetterSolutions = []string{
+		"home", "link", "hand", "sell", "song", "tiny", "drew", "text", "hear", "tale",
+		"turn", "shut", "lead", "diet", "dust", "bomb", "more", "wide", "cool", "baby",
+		"bond", "lady", "flow", "hurt", "part", "thin", "kept", "edge", "fate", "poll",
+		"thus", "room", "self", "navy", "side", "look", "acid", "gene", "pure", "mood",
+		"send", "type", "mine", "dead", "need", "star", "twin", "sand", "cold", "such",
+		"wash", "late", "most", "base", "tape", "rice", "lack", "debt", "laid", "hunt",
+		"drug", "seem", "army", "went", "park", "fast", "wood", "knee", "trip", "chat",
+		"film", "hope", "cost", "nine", "dear", "gift", "size", "idea", "held", "stop",
+		"mail", "rock", "tone", "bush", "skin", "plus", "move", "rush", "dark", "just",
+		"race", "week", "told", "bear", "find", "harm", "role", "tank", "keep", "rich",
+		"feel", "mass", "ride", "both", "camp", "core", "kind", "snow", "seat", "rate",
+		"hair", "news", "cash", "jury", "land", "gear", "kick", "sale", "cope", "open",
+		"tall", "busy", "rent", "body", "hero", "loan", "port", "bell", "wife", "must",
+		"felt", "fund", "east", "huge", "slip", "boat", "palm", "neck", "name", "rail",
+		"word", "year", "pack", "fine", "wait", "spot", "note", "wear", "play", "aged",
+		"walk", "mark", "ease", "rare", "bulk", "rely", "hung", "like", "form", "plug",
+		"half", "back", "plan", "away", "oral", "wave", "jump", "step", "vote", "zero",
+		"vast", "some", "moon", "give", "code", "exit", "true", "tool", "crew", "talk",
+		"time", "call", "weak", "able", "line", "mild", "mile", "task", "soul", "firm",
+		"heat", "hour", "page", "beat", "hold", "tend", "boss", "view", "shot", "bank",
+		"cast", "hole", "head", "deal", "best", "yard", "sole", "love", "date", "soil",
+		"soon", "wire", "wore", "coat", "gave", "feed", "left", "pair", "peak", "lane",
+		"zone", "hill", "deny", "plot", "sake", "want", "sign", "club", "nose", "suit",
+		"test", "tour", "wise", "unit", "iron", "work", "show", "meet", "safe", "main",
+		"west", "gone", "book", "golf", "dish", "meat", "made", "same", "down", "burn",
+		"crop", "lord", "none", "cook", "knew", "rear", "foot", "fuel", "full", "over",
+		"beer", "evil", "near", "fell", "lock", "pick", "seen", "list", "file", "wing",
+		"pink", "wake", "pull", "food", "sort", "miss", "fall", "less", "ring", "pace",
+		"seek", "belt", "slow", "tree", "much", "pass", "bill", "desk", "rank", "bone",
+		"tune", "wild", "come", "post", "load", "fear", "loss", "cell", "pool", "save",
+		"jazz", "read", "drop", "male", "take", "join", "area", "tide", "seed", "door",
+		"tell", "king", "free", "dawn", "will", "luck", "face", "risk", "gulf", "cake",
+		"road", "life", "dirt", "sick", "team", "shop", "ball", "high", "good", "came",
+		"site", "bird", "warm", "meal", "vice", "duty", "hire", "copy", "flat", "rise",
+		"push", "goal", "fire", "born", "chip", "here", "fair", "pipe", "rose", "help",
+		"rain", "lose", "inch", "easy", "well", "lost", "root", "rule", "farm", "term",
+		"wish", "fail", "ship", "deep", "host", "soft", "milk", "salt", "paid", "once",
+		"took", "roll", "mean", "blue", "calm", "blow", "gain", "hard", "fish", "dose",
+		"make", "long", "gray", "real", "live", "lift", "wall", "nice", "have", "sold",
+		"poor", "grow", "also", "hall", "past", "care", "gold", "mind", "fort", "earn",
+		"four", "next", "know", "sent", "holy", "rest", "card", "keen", "sure", "roof",
+		"wine", "hate", "draw", "fact", "girl", "wind", "game", "wage", "said", "bath",
+		"feet", "gate", "coal", "dual", "town", "glad", "item", "pain", "only", "fill",
+		"boom", "till", "very", "grew", "path", "last", "band", "bowl", "case", "disc",
+		"mode", "lake", "stay", "city", "hang", "five",
+	}
+	// sorted 4 letter words that are allowed as guesses, but are never solutions. This
+	// is only a short list of common words, a full dictionary can be added with LoadWordBanks
+	FourLetterAllowedWords = []string{
+		"acne", "acre", "aide", "ajar", "ally", "amid", "anew", "ante", "apex", "arch",
+		"aria", "arid", "arms", "atom", "aunt", "aura", "auto", "avid", "axis", "axle",
+		"bail", "bait", "bake", "bald", "bale", "bare", "bark", "barn", "bass", "bead",
+		"beak", "beam", "bean", "beef", "been", "bend", "bent", "bike", "bind", "bite",
+		"blew", "blot", "blur", "boar", "bold", "bolt", "bore", "bout", "brag", "brat",
+		"bred", "brew", "brim", "bulb", "bull", "bump", "bunk", "buoy", "burp", "bust",
+		"butt", "buzz", "cafe", "cage", "calf", "cane", "cape", "carp", "cart", "cave",
+		"cent", "chef", "chin", "chop", "cite", "clad", "clam", "clan", "clap", "claw",
+		"clay", "clip", "clog", "clot", "coil", "coin", "coke", "colt", "comb", "cone",
+		"cord", "cork", "corn", "cove", "crab", "crib", "crow", "cube", "cuff", "cult",
+		"curb", "cure", "curl", "cute", "dame", "damp", "dare", "dart", "dash", "data",
+		"days", "deck", "deed", "deer", "dent", "dice", "dime", "dine", "dive", "dock",
+		"doll", "dome", "doom", "dove", "drag", "drum", "duck", "duel", "dull", "dumb",
+		"dump", "dune", "dusk", "each", "echo", "else", "envy", "epic", "even", "ever",
+		"fade", "fake", "fame", "fang", "fare", "fawn", "feat", "fern", "fist", "flag",
+		"flap", "flaw", "flea", "fled", "flew", "flip", "foam", "foil", "fold", "folk",
+		"fond", "font", "fool", "fork", "foul", "fowl", "fray", "fret", "frog", "from",
+		"fume", "fury", "fuse", "fuss", "gale", "gall", "gang", "gasp", "gaze", "germ",
+		"gild", "glee", "glow", "glue", "gnat", "gnaw", "goat", "goes", "gown", "grab",
+		"grid", "grim", "grin", "grip", "grit", "gust", "hail", "halt", "hare", "harp",
+		"hawk", "haze", "heal", "heap", "heel", "heir", "herb", "herd", "hike", "hint",
+		"hive", "hoax", "hood", "hoof", "hook", "hoop", "horn", "hose", "howl", "hull",
+		"hymn", "icon", "idle", "idol", "into", "iris", "isle", "itch", "jade", "jail",
+		"jaws", "jeer", "jerk", "jest", "jolt", "keel", "kelp", "kiln", "kilt", "kite",
+		"knit", "knob", "knot", "lace", "lamb", "lamp", "lard", "lash", "lava", "lawn",
+		"leaf", "leak", "lean", "leap", "lens", "liar", "lick", "limb", "lime", "limp",
+		"lint", "lion", "loaf", "lobe", "loft", "logo", "loin", "loom", "loop", "lure",
+		"lurk", "lush", "lynx", "mace", "maid", "malt", "mane", "many", "mare", "mash",
+		"mask", "mast", "mate", "maze", "mead", "meek", "melt", "menu", "mesh", "mice",
+		"mill", "mime", "mint", "mist", "moan", "moat", "mock", "mold", "mole", "molt",
+		"monk", "moss", "moth", "mule", "muse", "mush", "musk", "mute", "myth", "nail",
+		"nest", "newt", "node", "noon", "norm", "oath", "oboe", "odor", "okay", "omen",
+		"oven", "oxen", "pale", "pane", "pant", "pave", "pawn", "peal", "pear", "peat",
+		"peel", "peer", "perk", "pest", "pier", "pile", "pine", "pint", "plea", "plod",
+		"plum", "poem", "poet", "pole", "pond", "pony", "pore", "pose", "pour", "prey",
+		"prod", "prow", "puck", "puff", "pulp", "puma", "pump", "punk", "purr", "quay",
+		"quit", "quiz", "raft", "rage", "raid", "rake", "ramp", "rash", "rave", "reed",
+		"reef", "reel", "rein", "rind", "riot", "ripe", "robe", "rode", "romp", "rope",
+		"rosy", "rude", "ruin", "rung", "rust", "sack", "sage", "sail", "sane", "sang",
+		"sash", "scam", "scar", "seal", "seam", "sear", "shed", "shin", "shoe", "sift",
+		"silk", "silo", "sing", "sink", "sire", "skid", "skip", "slab", "slam", "slap",
+		"sled", "slid", "slim", "slot", "slug", "smog", "snag", "snap", "snip", "snob",
+		"soak", "soap", "soar", "sock", "soda", "sofa", "soot", "sour", "span", "spin",
+		"spit", "spur", "stab", "stag", "stem", "stew", "stir", "stub", "stun", "swam",
+		"swan", "swap", "sway", "swim", "tack", "tact", "tail", "tame", "tarp", "tart",
+		"taxi", "teal", "tear", "tech", "teen", "tent", "than", "that", "them", "then",
+		"they", "this", "tile", "tilt", "toad", "toil", "toll", "tomb", "tore", "toss",
+		"tote", "tray", "trim", "trot", "tuba", "tuck", "tusk", "twig", "undo", "upon",
+		"urge", "user", "vain", "vase", "veil", "vein", "vent", "verb", "vest", "veto",
+		"vial", "vine", "void", "wade", "waft", "wail", "wand", "ward", "wary", "wasp",
+		"ways", "weed", "weld", "were", "what", "when", "whim", "whip", "whiz", "whom",
+		"wick", "wilt", "wimp", "wink", "wipe", "wisp", "with", "woke", "wolf", "womb",
+		"wool", "worm", "wove", "wrap", "wren", "yarn", "yawn", "yeah", "yell", "yoga",
+		"yoke", "yolk", "your", "zeal", "zest", "zinc", "zoom",
+	}
+)
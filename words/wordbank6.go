@@ -0,0 +1,106 @@
+package words
+
+var (
+	// in-order solutions for 6 letter puzzles. index 0 = puzzle 1
+	SixLetterSolutions = []string{
+		"choose", "person", "estate", "happen", "forget", "minute", "afraid", "moment", "screen", "memory",
+		"former", "repair", "border", "energy", "simply", "detect", "attack", "exceed", "branch", "handle",
+		"corner", "demand", "walker", "mobile", "writer", "defend", "autumn", "random", "anyway", "vision",
+		"figure", "bureau", "arrive", "scheme", "likely", "raised", "aspect", "second", "closed", "prison",
+		"annual", "remove", "decide", "silver", "fiscal", "labour", "except", "saving", "normal", "leader",
+		"ground", "weight", "relief", "golden", "direct", "summit", "friend", "stroke", "chosen", "relate",
+		"follow", "server", "bright", "extent", "defeat", "degree", "tennis", "nights", "belong", "symbol",
+		"decade", "candle", "effort", "manner", "column", "sudden", "charge", "linked", "rising", "career",
+		"artist", "forced", "partly", "phrase", "basket", "honest", "bought", "device", "myself", "really",
+		"select", "appear", "tender", "launch", "spread", "street", "coffee", "listen", "hidden", "twenty",
+		"prince", "recall", "retail", "reason", "battle", "suffer", "failed", "mostly", "deputy", "bottle",
+		"signal", "assist", "dinner", "domain", "active", "choice", "ethnic", "patent", "agenda", "valley",
+		"costly", "latest", "steady", "father", "notice", "budget", "little", "option", "solely", "finger",
+		"entity", "employ", "behalf", "finish", "status", "castle", "height", "sister", "flower", "ticket",
+		"winter", "across", "pocket", "luxury", "crisis", "people", "shadow", "nobody", "though", "access",
+		"entire", "engine", "growth", "client", "robust", "series", "palace", "enough", "breath", "silent",
+		"update", "ensure", "eating", "fallen", "player", "proven", "narrow", "either", "thanks", "nearby",
+		"winner", "copper", "window", "author", "rarely", "legacy", "driver", "mutual", "avenue", "ending",
+		"strong", "united", "filter", "become", "target", "coming", "holder", "flying", "mirror", "almost",
+		"global", "riding", "secure", "behind", "moving", "itself", "future", "nearly", "source", "matter",
+		"excess", "reader", "medium", "latter", "middle", "create", "design", "depend", "doctor", "unique",
+		"caught", "twelve", "worker", "nation", "sector", "county", "script", "office", "seller", "health",
+		"indeed", "animal", "origin", "spirit", "online", "settle", "engage", "severe", "public", "sample",
+		"dollar", "desert", "police", "survey", "region", "pursue", "parent", "hardly", "actual", "flight",
+		"during", "detail", "family", "rescue", "inside", "letter", "carbon", "lights", "fabric", "export",
+		"wonder", "appeal", "method", "injury", "expert", "cancer", "orange", "barely", "liquid", "making",
+		"studio", "bottom", "return", "anyone", "chance", "facing", "double", "weekly", "toward", "number",
+		"borrow", "differ", "volume", "beauty", "please", "smooth", "reward", "advise", "visual", "church",
+		"regard", "attend", "review", "stable", "frozen", "bridge", "amount", "couple", "assume", "length",
+		"action", "obtain", "salary", "circle", "secret", "thirty", "versus", "reform", "gather", "closer",
+		"foster", "fairly", "living", "school", "member", "saying", "center", "master", "manage", "mainly",
+		"resort", "surely", "within", "pretty", "intend", "import", "editor", "remote", "famous", "reduce",
+		"stolen", "effect", "timber", "taking", "speech", "system", "button", "unable", "varied", "afford",
+		"common", "threat", "lesson", "emerge", "treaty", "behave", "talent", "spoken", "lovely", "island",
+		"modest", "became", "should", "report", "leaves", "search", "spring", "vendor", "square", "lawyer",
+		"submit", "headed", "picked", "intent", "course", "assess", "useful", "social", "camera", "equity",
+		"guilty", "museum", "gender", "victim", "expect", "profit", "proper", "repeat", "object", "fellow",
+		"record", "affect", "combat", "credit", "advice", "mother", "yellow", "define", "planet", "casual",
+		"desire", "marble", "gentle", "supply", "season", "extend", "empire", "thrown", "policy", "broken",
+		"unlike", "motion", "result", "mental", "theory", "burden", "struck", "forest", "expand", "junior",
+		"plenty", "driven", "taught", "rather", "always", "merely", "ruling", "recent", "packed", "modern",
+		"damage", "marine", "formal", "regime", "debate", "permit", "around", "bishop", "escape", "stream",
+		"rating", "switch", "danger", "simple", "trying", "tissue", "league", "easily", "timing", "impact",
+		"stress", "format", "better", "female", "invest", "tenant", "belief", "reveal", "prefer", "strike",
+		"wealth", "eleven", "unless", "string", "safety", "notion", "offset", "custom", "beyond", "retain",
+		"nature", "native", "income", "travel", "seeing", "dealer", "strict", "market", "enable", "agency",
+		"sought", "factor", "garden", "senior", "single", "losing", "period", "remain", "change", "accept",
+		"answer", "eighth", "slight", "margin", "before", "summer", "output", "strain",
+	}
+	// sorted 6 letter words that are allowed as guesses, but are never solutions. This
+	// is only a short list of common words, a full dictionary can be added with LoadWordBanks
+	SixLetterAllowedWords = []string{
+		"aboard", "absent", "absorb", "accent", "accuse", "adjust", "admire", "adrift", "advent", "aerial",
+		"afloat", "agreed", "ailing", "aiming", "alight", "allege", "allies", "allure", "almond", "alpine",
+		"amends", "anchor", "angler", "anthem", "antler", "apathy", "arcade", "arctic", "ardent", "arrows",
+		"ascend", "ashore", "asleep", "aspire", "assent", "assert", "assign", "asylum", "attain", "attire",
+		"avatar", "awaken", "badger", "bakery", "ballad", "bamboo", "banana", "bandit", "banner", "banter",
+		"barber", "barrel", "basalt", "beacon", "beaker", "beetle", "beggar", "bellow", "berate", "bestow",
+		"betray", "bicker", "bikini", "billow", "binder", "bisect", "blazer", "blight", "blonde", "blouse",
+		"bodily", "boiler", "bonnet", "botany", "bounce", "bounty", "brainy", "breezy", "bridle", "broker",
+		"bronze", "brooch", "bubble", "bucket", "buckle", "bundle", "bungle", "burial", "burrow", "butler",
+		"butter", "cactus", "candid", "canine", "canopy", "canvas", "canyon", "carpet", "carrot", "cashew",
+		"casino", "cattle", "cellar", "cement", "centre", "cereal", "chalet", "cheese", "cherry", "chisel",
+		"chorus", "chrome", "cinema", "citrus", "clergy", "clumsy", "cobalt", "cobweb", "coerce", "collar",
+		"comedy", "commit", "convey", "cookie", "corpse", "cosmic", "cotton", "cougar", "cradle", "crafty",
+		"crater", "creamy", "crunch", "cuddle", "cursor", "cymbal", "dainty", "dazzle", "debris", "deceit",
+		"decent", "decode", "deduce", "deemed", "delete", "dental", "depict", "deploy", "deport", "derive",
+		"despot", "devote", "devour", "dilute", "dimmer", "dismal", "divide", "donkey", "dragon", "drawer",
+		"dreamy", "drench", "easier", "effigy", "elapse", "eldest", "emblem", "embryo", "enamel", "encode",
+		"endure", "enigma", "enrich", "enroll", "entail", "enzyme", "errand", "exhale", "exodus", "exotic",
+		"expire", "fabled", "facade", "falcon", "famine", "fathom", "faucet", "fennel", "ferret", "fiasco",
+		"fickle", "fiddle", "fidget", "fierce", "filthy", "flavor", "fleece", "flinch", "floppy", "fluffy",
+		"fodder", "forage", "forbid", "forgot", "fossil", "fridge", "frisky", "fungus", "funnel", "gadget",
+		"galaxy", "gallon", "gamble", "garage", "garlic", "gazebo", "geyser", "giggle", "ginger", "glider",
+		"gloomy", "goblin", "gospel", "gossip", "gravel", "grease", "grumpy", "guitar", "hammer", "hamper",
+		"handed", "harbor", "hazard", "helmet", "herbal", "hermit", "hiccup", "hijack", "hinder", "hoodie",
+		"hornet", "humble", "hunger", "hurdle", "hustle", "iguana", "impure", "incite", "indoor", "infant",
+		"inform", "inhale", "inject", "insect", "insult", "invade", "jacket", "jaguar", "jigsaw", "jockey",
+		"jostle", "jumble", "jungle", "kennel", "kernel", "kidney", "killed", "kitten", "knight", "ladder",
+		"lagoon", "lament", "laptop", "lavish", "layout", "legend", "lentil", "lesion", "lizard", "locker",
+		"locket", "lumber", "magnet", "maiden", "mallet", "mammal", "mantle", "marvel", "meadow", "mellow",
+		"melody", "menace", "mentor", "meteor", "mingle", "minnow", "mitten", "mohair", "morsel", "mosaic",
+		"muffin", "murder", "muscle", "muzzle", "napkin", "nectar", "needle", "nephew", "nibble", "nickel",
+		"noodle", "nozzle", "nugget", "nutmeg", "oblong", "oyster", "paddle", "pajama", "pallet", "parade",
+		"parrot", "pastry", "peanut", "pebble", "pedant", "pepper", "petrol", "pewter", "pickle", "pigeon",
+		"pillow", "pirate", "pistol", "plaque", "pliers", "plunge", "poetry", "poison", "pollen", "potato",
+		"powder", "prayer", "prefix", "puddle", "puffin", "pulley", "pumice", "puppet", "purple", "puzzle",
+		"quaint", "quiver", "rabbit", "racket", "radish", "raisin", "ransom", "rattle", "ravine", "reboot",
+		"recipe", "refuel", "relish", "remedy", "ribbon", "riddle", "ripple", "rocket", "rodent", "rubber",
+		"rudder", "rustic", "saddle", "safari", "salmon", "saucer", "scarce", "scenic", "sesame", "sexual",
+		"shiver", "shovel", "shrimp", "shrine", "sickle", "simmer", "sketch", "skewer", "slogan", "sluice",
+		"smudge", "sneeze", "sniper", "snooze", "socket", "sorbet", "sphere", "spider", "spinal", "splash",
+		"sponge", "sprout", "squash", "squint", "squirm", "stanza", "staple", "starch", "stench", "stitch",
+		"stucco", "sturdy", "subtle", "sultan", "summon", "sunset", "superb", "surfer", "swerve", "tablet",
+		"tactic", "tailor", "tangle", "teapot", "temple", "tether", "thorny", "thrill", "throne", "tickle",
+		"tinder", "toasty", "tomato", "tongue", "toucan", "trophy", "tundra", "turkey", "turnip", "tuxedo",
+		"tycoon", "unfold", "unjust", "upbeat", "uproar", "urchin", "utmost", "vacuum", "vanish", "velvet",
+		"violin", "voyage", "waffle", "walnut", "walrus", "wander", "warmth", "weasel", "wicker", "wiggle",
+		"wobble", "wombat", "wreath", "yogurt", "zealot", "zenith", "zipper", "zombie",
+	}
+)
@@ -0,0 +1,94 @@
+package words
+
+var (
+	// in-order solutions for 7 letter puzzles. index 0 = puzzle 1
+	SevenLetterSolutions = []string{
+		"suspect", "painter", "amazing", "wedding", "surgery", "release", "mission", "portion", "decline", "engaged",
+		"railway", "college", "missing", "absence", "federal", "protest", "lasting", "western", "advised", "reflect",
+		"passing", "patient", "opinion", "fortune", "exactly", "thereby", "content", "excited", "culture", "chicken",
+		"natural", "airport", "weekend", "nursing", "capital", "episode", "require", "adverse", "instead", "contact",
+		"discuss", "equally", "pension", "veteran", "receipt", "accused", "retired", "forever", "fifteen", "combine",
+		"service", "website", "assault", "knowing", "telling", "weather", "counter", "premium", "element", "warrant",
+		"gallery", "connect", "greater", "towards", "dealing", "clothes", "unknown", "drawing", "classic", "publish",
+		"control", "advance", "economy", "comfort", "strange", "crucial", "against", "surplus", "instant", "winning",
+		"optical", "picture", "setting", "tonight", "monthly", "witness", "similar", "uniform", "compare", "project",
+		"failing", "healthy", "alcohol", "maximum", "collect", "segment", "analyst", "explore", "counsel", "section",
+		"suppose", "leading", "fashion", "mixture", "payable", "certain", "channel", "wearing", "traffic", "removed",
+		"dispute", "whether", "destroy", "somehow", "convert", "disease", "premier", "founder", "gesture", "written",
+		"confirm", "decided", "sitting", "already", "neutral", "silence", "charity", "habitat", "species", "hunting",
+		"kingdom", "pattern", "minimal", "partner", "showing", "capable", "growing", "fiction", "regular", "promote",
+		"surface", "account", "revenue", "reality", "replace", "eastern", "respect", "housing", "density", "liberal",
+		"perform", "evening", "bearing", "warning", "summary", "mention", "because", "feeling", "hundred", "perhaps",
+		"contest", "anxious", "printer", "initial", "profile", "binding", "measure", "special", "contain", "program",
+		"feature", "anxiety", "protein", "passive", "failure", "deposit", "predict", "violent", "message", "waiting",
+		"minimum", "sixteen", "builder", "intense", "private", "nuclear", "resolve", "restore", "primary", "complex",
+		"speaker", "version", "compete", "example", "several", "illegal", "journey", "deliver", "checked", "various",
+		"address", "welfare", "interim", "support", "journal", "network", "capture", "survive", "passion", "context",
+		"however", "caution", "holding", "supreme", "largely", "quarter", "holiday", "exclude", "ongoing", "express",
+		"satisfy", "diamond", "hearing", "recover", "divided", "caption", "purpose", "concern", "vehicle", "pending",
+		"machine", "produce", "impress", "fitness", "typical", "insight", "factory", "tension", "reserve", "popular",
+		"succeed", "keeping", "dynamic", "finding", "serious", "society", "meaning", "percent", "leisure", "medical",
+		"achieve", "display", "privacy", "monitor", "poverty", "partial", "diverse", "routine", "virtual", "totally",
+		"between", "expense", "limited", "liberty", "sponsor", "logical", "promise", "ceiling", "receive", "nervous",
+		"provide", "digital", "climate", "getting", "viewing", "barrier", "airline", "funding", "burning", "whereas",
+		"picking", "ability", "sustain", "arrival", "request", "captain", "command", "integer", "council", "balance",
+		"consist", "reading", "without", "library", "calling", "helpful", "himself", "proceed", "problem", "therapy",
+		"related", "article", "mineral", "century", "extreme", "victory", "general", "musical", "science", "circuit",
+		"academy", "embrace", "distant", "conduct", "parking", "listing", "acquire", "deficit", "pointed", "venture",
+		"concept", "benefit", "reverse", "default", "grocery", "forward", "husband", "readily", "present", "inquiry",
+		"charter", "edition", "nothing", "history", "session", "compact", "silicon", "remains", "heavily", "unusual",
+		"develop", "chamber", "serving", "crystal", "success", "manager", "careful", "penalty", "company", "formula",
+		"kitchen", "adviser", "fishing", "respond", "handful", "emotion", "involve", "student", "utility", "prepare",
+		"exhibit", "radical", "variety", "backing", "process", "qualify", "learned", "driving", "blanket", "walking",
+		"justify", "outside", "studied", "passage", "foreign", "pushing", "filling", "station", "outcome", "turning",
+		"beating", "product", "another", "imagine", "loyalty", "writing", "storage", "running", "comment", "touched",
+		"million", "through", "willing", "payment", "enhance", "jointly", "removal", "finance", "married", "mistake",
+		"someone", "outdoor", "bedroom", "despite", "shortly", "quality", "teacher", "skilled", "devoted", "examine",
+		"freedom", "justice", "upgrade", "landing", "explain", "seventh", "include", "morning", "propose", "massive",
+		"correct", "perfect", "village", "opening", "average", "realize", "notable", "brought", "genuine", "consent",
+		"precise", "trouble", "overall", "cabinet", "concert", "protect", "stretch", "officer", "execute", "organic",
+		"carrier", "neither", "chronic", "wanting", "enquiry", "chapter", "thought", "obvious", "anybody", "current",
+		"arrange", "highway", "painted", "outlook", "auction", "welcome", "pioneer", "plastic", "cutting", "central",
+		"operate", "suggest", "attract", "improve", "nowhere", "biology", "desktop", "banking", "illness", "install",
+		"billion", "ancient", "country", "meeting", "visible", "attempt", "package", "funeral", "brother", "mystery",
+		"subject", "battery", "applied", "prevent", "herself", "closing", "working", "faculty",
+	}
+	// sorted 7 letter words that are allowed as guesses, but are never solutions. This
+	// is only a short list of common words, a full dictionary can be added with LoadWordBanks
+	SevenLetterAllowedWords = []string{
+		"abandon", "abolish", "absolve", "acrobat", "adamant", "admiral", "adopted", "alchemy", "almanac", "amateur",
+		"ambient", "amnesty", "amplify", "anagram", "anatomy", "angular", "animate", "antenna", "apricot", "aquatic",
+		"archive", "arsenal", "artisan", "ascetic", "asphalt", "athlete", "avocado", "awkward", "bagpipe", "balcony",
+		"ballast", "balloon", "bandage", "banquet", "bargain", "barrack", "bashful", "bastion", "beehive", "begonia",
+		"bicycle", "biscuit", "blender", "blister", "blossom", "blunder", "boulder", "bouquet", "bracket", "bramble",
+		"brewery", "brocade", "buffalo", "buffoon", "bulldog", "bullion", "burglar", "cabbage", "cadence", "caliber",
+		"calorie", "canteen", "cardiac", "caribou", "cartoon", "cashier", "catalog", "cavalry", "cellist", "chalice",
+		"chariot", "cheetah", "chimney", "chowder", "citadel", "clarity", "cleaver", "coastal", "cobbler", "coconut",
+		"cologne", "compass", "compost", "conifer", "cookout", "coroner", "corsage", "costume", "cottage", "courage",
+		"cracker", "crevice", "cricket", "crimson", "croquet", "cruiser", "cubicle", "cuisine", "culprit", "cunning",
+		"cupcake", "cushion", "cyclone", "dancing", "decimal", "defence", "delight", "dentist", "dessert", "detract",
+		"diagram", "dilemma", "disband", "dolphin", "doorway", "drastic", "dribble", "drizzle", "dungeon", "durable",
+		"dwindle", "eclipse", "ecology", "elastic", "elegant", "elevate", "emerald", "empathy", "emperor", "enchant",
+		"eternal", "ethical", "exhaust", "fanfare", "fantasy", "feather", "fertile", "festive", "fiddler", "fitting",
+		"flannel", "flutter", "foliage", "fragile", "freckle", "furnace", "gallant", "gazelle", "giraffe", "glacier",
+		"glimmer", "glimpse", "gondola", "gorilla", "gourmet", "grammar", "granite", "gravity", "griddle", "grimace",
+		"gristle", "gumdrop", "halibut", "hamster", "harbour", "harmony", "harvest", "hatchet", "haughty", "heroine",
+		"hexagon", "hickory", "hideout", "holster", "horizon", "hostage", "hydrant", "iceberg", "impulse", "incense",
+		"inferno", "inkwell", "jasmine", "javelin", "jealous", "jewelry", "juggler", "jukebox", "karaoke", "kestrel",
+		"ketchup", "killing", "kindred", "knuckle", "lantern", "lattice", "leopard", "lettuce", "licence", "lobster",
+		"luggage", "lullaby", "madness", "mammoth", "mandate", "mansion", "marquee", "martial", "mascara", "meander",
+		"measles", "mermaid", "migrant", "miracle", "mollusk", "monsoon", "mustard", "narwhal", "nemesis", "nostril",
+		"oatmeal", "octagon", "octopus", "offence", "orchard", "ostrich", "outrage", "oxidize", "paprika", "paradox",
+		"parsley", "partake", "pelican", "penguin", "perfume", "pharaoh", "pianist", "pilgrim", "pinball", "pitcher",
+		"plateau", "polygon", "pottery", "poultry", "prairie", "pretzel", "primate", "prodigy", "pudding", "pumpkin",
+		"pyramid", "quarrel", "quartet", "quibble", "raccoon", "rainbow", "rampage", "rapport", "recital", "redwood",
+		"reptile", "romance", "rooftop", "rosebud", "rotunda", "sardine", "satchel", "sausage", "scallop", "scarlet",
+		"scepter", "scholar", "scratch", "seagull", "serpent", "sheriff", "shimmer", "shrivel", "sirloin", "skeptic",
+		"smoking", "snorkel", "soprano", "sparkle", "sparrow", "spatula", "spinach", "splotch", "squeeze", "stadium",
+		"stapler", "starter", "steward", "stomach", "strudel", "sunbeam", "sunrise", "swallow", "sweater", "tadpole",
+		"tangent", "tapioca", "tempest", "terrace", "texture", "theatre", "thistle", "thunder", "tornado", "tractor",
+		"trapeze", "treetop", "trident", "trinket", "trumpet", "tugboat", "turbine", "twinkle", "typhoon", "unicorn",
+		"utensil", "vampire", "vanilla", "venison", "verdict", "vibrant", "vintage", "volcano", "voucher", "vulture",
+		"wallaby", "warrior", "wetland", "whisker", "whistle", "wildcat", "wrestle", "yelling", "zealous",
+	}
+)
@@ -0,0 +1,85 @@
+package words
+
+var (
+	// in-order solutions for 8 letter puzzles. index 0 = puzzle 1
+	EightLetterSolutions = []string{
+		"medieval", "wildlife", "training", "dramatic", "terminal", "delicate", "original", "schedule", "integral", "variable",
+		"commence", "traveled", "everyone", "finished", "probable", "guidance", "junction", "southern", "facility", "modeling",
+		"syndrome", "response", "consider", "received", "shipping", "practice", "monetary", "politics", "accuracy", "autonomy",
+		"platform", "equality", "increase", "absolute", "lighting", "parallel", "isolated", "confused", "surgical", "negative",
+		"employee", "opponent", "minister", "domestic", "industry", "computer", "artistic", "proposal", "medicine", "survival",
+		"violence", "relative", "athletic", "language", "decision", "business", "alliance", "printing", "petition", "civilian",
+		"election", "disorder", "optional", "optimism", "distinct", "ceremony", "attached", "aviation", "resident", "sampling",
+		"multiple", "tracking", "director", "possible", "secondly", "disabled", "campaign", "resigned", "fraction", "mobility",
+		"ministry", "dominant", "contract", "purchase", "customer", "informed", "exchange", "suburban", "hospital", "situated",
+		"universe", "yourself", "reliance", "peaceful", "advisory", "reserved", "nineteen", "floating", "pleasure", "moreover",
+		"intimate", "dominate", "observer", "suitable", "weighted", "involved", "directly", "physical", "activity", "separate",
+		"tomorrow", "research", "coverage", "interior", "engaging", "audience", "touching", "evidence", "foremost", "adjusted",
+		"pipeline", "material", "dropping", "straight", "dressing", "sergeant", "painting", "circular", "featured", "vertical",
+		"decrease", "recently", "protocol", "dividend", "marginal", "conflict", "princess", "valuable", "catching", "preserve",
+		"solution", "external", "sterling", "convince", "envelope", "everyday", "addition", "shoulder", "overhead", "identity",
+		"pleasant", "anything", "duration", "striking", "patience", "profound", "anywhere", "wherever", "clearing", "receiver",
+		"midnight", "accurate", "symbolic", "minimize", "heritage", "restrict", "precious", "eventual", "analysis", "taxation",
+		"approval", "unlawful", "goodwill", "interval", "laughter", "keyboard", "register", "provider", "feedback", "meantime",
+		"landlord", "chemical", "mountain", "probably", "personal", "database", "triangle", "portable", "software", "guardian",
+		"relation", "progress", "whatever", "enormous", "swimming", "standing", "simplify", "parental", "discover", "pregnant",
+		"previous", "clinical", "likewise", "disposal", "doctrine", "disclose", "property", "deciding", "ordinary", "contrast",
+		"somebody", "emphasis", "sweeping", "homeless", "invasion", "sequence", "republic", "division", "prospect", "ideology",
+		"turnover", "endeavor", "momentum", "commerce", "calendar", "occasion", "mortgage", "eighteen", "covering", "transfer",
+		"reporter", "bulletin", "approach", "relevant", "patented", "seasonal", "instance", "graduate", "organize", "humanity",
+		"sentence", "composed", "teaching", "publicly", "incident", "workshop", "movement", "superior", "corridor", "fourteen",
+		"strength", "doubtful", "aluminum", "weakness", "entrance", "offshore", "tropical", "equation", "bachelor", "renowned",
+		"educated", "accident", "critical", "generate", "estimate", "detailed", "magazine", "somewhat", "scrutiny", "breaking",
+		"revision", "indirect", "football", "adjacent", "accepted", "deadline", "notebook", "category", "priority", "apparent",
+		"shortage", "appendix", "describe", "tactical", "positive", "graphics", "judgment", "boundary", "maturity", "inherent",
+		"withdraw", "intended", "sympathy", "champion", "function", "comprise", "thirteen", "supposed", "delivery", "cultural",
+		"baseball", "tailored", "initiate", "indicate", "exciting", "contrary", "consumer", "specific", "affected", "assembly",
+		"volatile", "location", "academic", "forecast", "evaluate", "familiar", "province", "chairman", "ultimate", "takeover",
+		"explicit", "producer", "actually", "creation", "strategy", "overseas", "romantic", "unlikely", "maintain", "compound",
+		"flexible", "memorial", "thousand", "capacity", "whenever", "terrible", "breeding", "economic", "attitude", "opposite",
+		"bacteria", "recovery", "thorough", "inspired", "national", "historic", "colonial", "diabetes", "informal", "northern",
+		"constant", "remember", "thinking", "repeated", "regional", "emerging", "concrete", "currency", "advanced", "tendency",
+		"congress", "cellular", "grateful", "resource", "continue", "identify", "reaction", "eligible", "woodland", "distance",
+		"imperial", "aircraft", "together", "required", "exposure", "electric", "achieved", "disaster", "extended", "definite",
+		"moderate", "sensible", "warranty", "attorney", "presence", "marriage", "dynamics", "merchant", "security", "complete",
+		"engineer", "crossing", "persuade", "magnetic", "umbrella", "position", "slightly", "standard", "entirely", "surprise",
+		"military", "taxpayer", "overcome", "scenario", "conclude", "clothing", "included", "festival", "mounting", "earnings",
+		"innocent", "operator", "religion", "interest", "offering", "complain", "criminal", "question", "lifetime", "although",
+		"minority", "tangible", "overview", "colorful", "speaking", "official", "daughter", "maximize", "powerful", "literary",
+		"becoming", "provided", "diameter", "exercise", "argument", "pressing", "casualty", "friendly", "struggle", "periodic",
+		"generous", "assuming", "sporting", "building", "district", "pressure", "cautious", "reliable", "frontier", "judicial",
+		"highland", "measured", "document", "quantity", "adequate", "rigorous", "dialogue", "learning", "birthday", "hardware",
+		"stunning", "treasury", "wireless", "numerous", "limiting", "governor", "interact", "leverage", "internal", "advocate",
+		"portrait", "handling", "daylight", "majority", "spectrum", "discount", "collapse", "bathroom", "announce", "rational",
+		"creative", "children", "frequent", "formerly", "designer",
+	}
+	// sorted 8 letter words that are allowed as guesses, but are never solutions. This
+	// is only a short list of common words, a full dictionary can be added with LoadWordBanks
+	EightLetterAllowedWords = []string{
+		"abstract", "accolade", "acoustic", "adorable", "aerobics", "affluent", "airplane", "airspace", "alphabet", "ambrosia",
+		"amethyst", "anteater", "antelope", "appetite", "applause", "aquarium", "armchair", "arrogant", "asteroid", "backpack",
+		"backyard", "bankrupt", "barbecue", "baritone", "barnacle", "baseline", "basement", "beautify", "beetroot", "believer",
+		"bewilder", "blackout", "blizzard", "blockade", "bluebell", "bookcase", "bookmark", "bracelet", "broccoli", "brunette",
+		"bungalow", "buttress", "calamity", "cardigan", "carnival", "cashmere", "catapult", "catholic", "cauldron", "cavalier",
+		"cemetery", "ceramics", "chestnut", "chipmunk", "chlorine", "cinnamon", "clarinet", "climbing", "cockatoo", "coleslaw",
+		"colossal", "confetti", "conquest", "cornmeal", "cosmetic", "cucumber", "cupboard", "daffodil", "dazzling", "deadlock",
+		"decorate", "delirium", "dinosaur", "diplomat", "disguise", "dominoes", "doorbell", "doughnut", "downtown", "drumbeat",
+		"dumbbell", "dumpling", "dwelling", "eggplant", "elephant", "elevator", "emporium", "escalate", "farewell", "ferocity",
+		"figurine", "fireball", "firewall", "firework", "flamingo", "flounder", "folklore", "foothill", "fragrant", "frighten",
+		"fruitful", "gardener", "gargoyle", "gemstone", "genomics", "geometry", "gigantic", "gingerly", "glorious", "goldfish",
+		"gorgeous", "graceful", "hallmark", "handbook", "handsome", "harmonic", "hazelnut", "headband", "hedgehog", "heirloom",
+		"homepage", "homework", "honeybee", "horrible", "hydrogen", "hysteria", "illusion", "imminent", "infinity", "insomnia",
+		"intranet", "jamboree", "jealousy", "jubilant", "juggling", "juvenile", "kangaroo", "kerosene", "kindling", "knapsack",
+		"ladybird", "landmark", "landmass", "laughing", "lavender", "lemonade", "lifeboat", "lipstick", "listener", "luminous",
+		"macaroni", "magician", "magnolia", "mahogany", "mandarin", "marathon", "marigold", "marmoset", "meatball", "mischief",
+		"molasses", "moonbeam", "mosquito", "mushroom", "musician", "navigate", "necklace", "nitrogen", "nocturne", "nutshell",
+		"obsidian", "oleander", "ornament", "overcast", "panorama", "paradise", "parakeet", "passport", "peculiar", "pendulum",
+		"perilous", "pheasant", "pinecone", "pinwheel", "playmate", "playtime", "plumbing", "polished", "porridge", "pristine",
+		"quadrant", "raindrop", "rambling", "recorder", "reindeer", "ricochet", "roadside", "rosemary", "rotation", "sailboat",
+		"sandwich", "sapphire", "scarcity", "scissors", "scorpion", "seashell", "semester", "serenade", "shepherd", "sidewalk",
+		"skeleton", "skylight", "slippery", "snowball", "snowfall", "snowshoe", "sparkler", "splendid", "squirrel", "stairway",
+		"standout", "starfish", "stingray", "sunlight", "sunshine", "swimsuit", "sycamore", "tapestry", "teaspoon", "tireless",
+		"tortoise", "tranquil", "treasure", "trombone", "unafraid", "unicycle", "upstairs", "vacation", "vagabond", "velocity",
+		"vineyard", "virtuoso", "volcanic", "wardrobe", "waterway", "windmill", "wishbone", "yearbook", "zucchini",
+	}
+)
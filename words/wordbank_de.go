@@ -0,0 +1,48 @@
+package words
+
+var (
+	// in-order solutions for German puzzles. index 0 = puzzle 1
+	GermanSolutions = []string{
+		"wüste", "kröte", "apfel", "blatt", "hütte", "deich", "reich", "frage", "dunst", "seife",
+		"sucht", "imker", "drama", "salbe", "laden", "wette", "rolle", "ziege", "laube", "leine",
+		"markt", "blume", "sorge", "panne", "runde", "lachs", "kampf", "waffe", "ruder", "backe",
+		"geist", "seide", "tempo", "krieg", "acker", "pedal", "tiger", "mulde", "lanze", "bruch",
+		"gabel", "honig", "piste", "platz", "reise", "pfeil", "folge", "beide", "heide", "karte",
+		"küste", "gurke", "mutig", "leben", "feier", "funke", "angst", "bitte", "stolz", "licht",
+		"nabel", "kleid", "stirn", "rasen", "fisch", "angel", "riese", "puder", "meise", "leise",
+		"boden", "stall", "hülle", "weide", "rubin", "druck", "wille", "hebel", "macht", "liste",
+		"heute", "weite", "nebel", "pilze", "kugel", "mühle", "kehle", "kiste", "blitz", "orgel",
+		"feder", "vater", "stock", "wolle", "truhe", "seite", "wiege", "enkel", "haupt", "eimer",
+		"kanne", "robbe", "glanz", "unfug", "onkel", "katze", "salat", "taube", "käfig", "musik",
+		"käfer", "hagel", "raupe", "pause", "kamel", "stadt", "alles", "feige", "kunst", "kappe",
+		"stroh", "tinte", "stahl", "frost", "fuchs", "nacht", "perle", "bauch", "sache", "bogen",
+		"liebe", "wurst", "degen", "feuer", "mitte", "schaf", "zwerg", "kette", "falle", "moral",
+		"hirte", "lehre", "stoff", "welle", "torte", "engel", "wunde", "mauer", "regen", "block",
+		"zeile", "faser", "krone", "damen", "rinde", "quark", "ernte", "leere", "blick", "pokal",
+		"knall", "milch", "bauer", "gummi", "jäger", "zange", "radio", "regel", "beere", "beruf",
+		"nudel", "knopf", "sense", "kurve", "säure", "paket", "klein", "palme", "spott", "tante",
+		"suppe", "traum", "kasse", "linie", "wespe", "rache", "säule", "abend", "mücke", "halle",
+		"regal", "essen", "nadel", "rippe", "hafen", "teich", "puppe", "sahne", "monat", "eisen",
+		"kreis", "fabel", "gnade", "plage", "magen", "leder", "beine", "brust", "staat", "weste",
+		"papst", "opfer", "dicht", "lampe", "haken", "sonne", "kakao", "schal", "hügel", "dreck",
+		"rasse", "alarm", "faden", "zunft", "atmen", "stuhl", "klima", "stift", "wolke", "kreuz",
+		"klage", "tritt", "kegel", "wache", "sport", "preis", "kerze", "spalt", "kohle", "kunde",
+		"falke", "lunge", "eiche", "birne", "tafel", "glück", "vogel", "messe", "farbe", "pflug",
+		"kraft", "möbel", "fahne", "probe", "masse", "stier", "tanne", "stamm", "kranz", "kanal",
+		"atlas", "seele", "sohle", "zweck", "samen", "stein", "stark", "asche", "segel", "tulpe",
+		"natur", "wiese", "meile", "nelke", "dampf", "zweig", "stiel", "trank", "flach", "pfahl",
+		"klotz", "watte", "kelch", "reife", "hitze", "kälte", "neffe", "zelle", "draht", "hüfte",
+		"narbe", "schuh", "dinge", "zunge", "hobel", "hotel", "brief", "fluss", "feind", "motor",
+		"klang", "spiel", "rauch", "brett", "tasse", "trieb", "staub", "biene", "besen", "insel",
+		"lager", "wagen", "busch", "brand", "wange", "tisch", "nagel", "loben", "pferd", "mönch",
+		"menge", "ebene", "lücke", "trost", "titel", "sorte", "treue", "speck", "jacke", "amsel",
+		"augen", "sitte", "stand", "kabel", "decke", "miete", "pilot", "sturm", "rumpf", "juwel",
+		"taste", "zebra", "fluch", "mappe", "würze", "grube", "hilfe", "stern", "kante", "stube",
+		"maske",
+	}
+	// sorted German words that are allowed as guesses, but are never solutions
+	GermanAllowedWords = []string{
+		"affen", "boote", "bären", "bäume", "eulen", "hunde", "hände", "löwen", "ringe", "rosen",
+		"teile", "uhren", "waren", "ziele",
+	}
+)
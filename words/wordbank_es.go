@@ -0,0 +1,52 @@
+package words
+
+var (
+	// in-order solutions for Spanish puzzles. index 0 = puzzle 1
+	SpanishSolutions = []string{
+		"llave", "audio", "coche", "parte", "yegua", "museo", "negro", "plata", "junto", "amigo",
+		"cabra", "reina", "hojas", "oreja", "trato", "latir", "carne", "gripe", "globo", "bajar",
+		"campo", "pared", "lobos", "pollo", "golpe", "actor", "jarra", "menta", "temor", "lucha",
+		"ruido", "rubio", "novia", "junio", "durar", "galgo", "error", "fondo", "mitad", "disco",
+		"igual", "hogar", "calor", "tarta", "nunca", "costa", "tarea", "posar", "tanto", "falso",
+		"gente", "juego", "lugar", "jamon", "noche", "horno", "grano", "nieve", "obras", "conde",
+		"bruja", "dieta", "libro", "crear", "echar", "corte", "cajon", "raton", "turno", "tinta",
+		"jugar", "canal", "pobre", "arena", "exito", "panal", "abrir", "lecho", "valor", "bicho",
+		"cuero", "regla", "abril", "nivel", "sabor", "julio", "tejer", "cazar", "reloj", "fuera",
+		"lapiz", "listo", "coral", "prisa", "atlas", "animo", "celda", "lavar", "datos", "paseo",
+		"silla", "prado", "trece", "crema", "razon", "icono", "untar", "cinco", "furia", "rueda",
+		"duque", "selva", "color", "suelo", "sudor", "mango", "dejar", "patio", "fiera", "enero",
+		"tirar", "tapar", "vapor", "gafas", "fruta", "cebra", "doble", "rigor", "siglo", "fumar",
+		"dolor", "apoyo", "ahora", "usted", "vigor", "caida", "poner", "nariz", "palma", "bello",
+		"santo", "grado", "dardo", "segun", "besar", "asado", "votar", "fresa", "picar", "preso",
+		"señor", "viaje", "justo", "robar", "sucio", "recto", "pelea", "resto", "remar", "breve",
+		"mosca", "media", "cerca", "letra", "bueno", "vivir", "talla", "decir", "debil", "vello",
+		"beber", "grasa", "copia", "angel", "moral", "queso", "pista", "final", "total", "mover",
+		"perro", "madre", "todos", "gotas", "pañal", "tocar", "odiar", "vuelo", "union", "huevo",
+		"gasto", "gorra", "motor", "cuota", "cerdo", "mujer", "creer", "pieza", "feliz", "causa",
+		"tumba", "antes", "verde", "mirar", "trago", "parar", "sauce", "norte", "lunes", "pasar",
+		"quien", "pisar", "baile", "sacar", "pilar", "mente", "hacer", "forma", "primo", "tarde",
+		"bahia", "venta", "nuevo", "salir", "sobre", "balon", "pinta", "venir", "pausa", "ocaso",
+		"nadar", "señal", "clavo", "veces", "secar", "banco", "agudo", "peral", "monte", "miedo",
+		"nubes", "pluma", "tenis", "piano", "zorro", "lejos", "viudo", "jabon", "orden", "comer",
+		"pedal", "barro", "largo", "sueño", "vista", "fuego", "hielo", "bolsa", "salto", "plomo",
+		"marco", "carro", "vacio", "linea", "poder", "marea", "salsa", "prima", "plato", "tomar",
+		"sonar", "dedos", "abeja", "rezar", "otoño", "ganso", "ancho", "rodar", "viejo", "polvo",
+		"peine", "rampa", "envio", "torre", "circo", "local", "entre", "tigre", "deuda", "lento",
+		"pedir", "cielo", "oeste", "rango", "menor", "aguja", "pulpo", "padre", "carta", "pinza",
+		"plaza", "gramo", "llama", "fecha", "punto", "oruga", "dueño", "gusto", "casco", "playa",
+		"mejor", "oasis", "rumbo", "mucho", "guapo", "cacao", "grupo", "tripa", "pecho", "metro",
+		"vicio", "dulce", "burro", "arroz", "techo", "libre", "hongo", "jaula", "suave", "trigo",
+		"firma", "viola", "etapa", "flaco", "cueva", "joven", "acero", "mismo", "calle", "salud",
+		"vagon", "vejez", "lider", "truco", "papel", "sitio", "joyas", "leche", "solar", "radio",
+		"tribu", "manta", "barco", "tabla", "oliva", "humor", "valle", "ganar", "hotel", "moler",
+		"lleno", "brazo", "volar", "cobre", "labio", "plano", "metal", "pagar", "virus", "cable",
+		"trapo", "arbol", "avion", "canto", "clase", "mayor", "senda", "mundo", "falda", "pasta",
+		"cruce", "traje", "yerno", "grave", "limon", "album", "cinta", "gordo", "zurdo", "ayuda",
+		"broma", "texto", "cañon", "pesca", "ritmo", "caoba", "morir", "grito", "subir",
+	}
+	// sorted Spanish words that are allowed as guesses, but are never solutions
+	SpanishAllowedWords = []string{
+		"baños", "casas", "gatos", "mapas", "mesas", "niños", "patos", "risas", "rocas", "sopas",
+		"tubos", "vacas", "vinos",
+	}
+)
@@ -0,0 +1,46 @@
+package words
+
+var (
+	// in-order solutions for Portuguese puzzles. index 0 = puzzle 1
+	PortugueseSolutions = []string{
+		"feliz", "sabao", "frase", "grito", "cravo", "marca", "molho", "saida", "salto", "viola",
+		"parar", "vespa", "pedra", "vazio", "bolso", "nivel", "dente", "antes", "rapaz", "abrir",
+		"macio", "cheio", "crise", "ouvir", "coisa", "coral", "navio", "cinto", "norte", "menor",
+		"medir", "todos", "gosto", "morar", "cunha", "papel", "braço", "tomar", "manga", "trigo",
+		"risco", "amora", "tinta", "massa", "limao", "pedir", "corpo", "untar", "canal", "aluno",
+		"cruel", "causa", "valor", "chuva", "forno", "sinal", "girar", "beijo", "ideia", "linha",
+		"amigo", "mundo", "cinco", "verbo", "menos", "museu", "jogar", "perna", "maior", "baixo",
+		"ontem", "renda", "rigor", "letra", "dever", "curso", "hotel", "graça", "curva", "haver",
+		"cedro", "serra", "julho", "tabua", "bomba", "copia", "claro", "olhar", "firme", "regra",
+		"pesca", "justo", "carro", "razao", "ganso", "cesta", "solar", "casco", "farol", "visao",
+		"viver", "remar", "senso", "venda", "oeste", "aguia", "quase", "enfim", "pasta", "vista",
+		"raiva", "ajuda", "lento", "banho", "fugir", "vinho", "leite", "rampa", "gasto", "telha",
+		"arroz", "selva", "andar", "ferro", "couve", "cerca", "falar", "queda", "brisa", "padre",
+		"prata", "sabor", "patio", "idade", "saude", "calma", "galho", "porco", "barco", "fazer",
+		"preço", "prova", "fraco", "força", "mover", "tanto", "perto", "valer", "carta", "prazo",
+		"golpe", "folha", "acima", "junho", "lapis", "canto", "festa", "largo", "dizer", "verde",
+		"achar", "metal", "ficar", "fundo", "cobra", "pouco", "velho", "bicho", "metro", "conto",
+		"longe", "misto", "igual", "aviao", "garfo", "muito", "tempo", "levar", "nariz", "morno",
+		"campo", "negro", "vidro", "tarde", "falta", "tecla", "praça", "areia", "piano", "subir",
+		"dueto", "nadar", "urubu", "algum", "tumba", "carne", "ritmo", "terra", "turno", "suave",
+		"passo", "breve", "limpo", "pausa", "palco", "tocar", "noite", "vapor", "ordem", "errar",
+		"preso", "troca", "mosca", "gesto", "trevo", "vento", "posse", "louco", "globo", "gripe",
+		"banco", "dados", "gente", "dança", "agora", "temer", "tenda", "nuvem", "pilha", "lenço",
+		"clima", "rosto", "saber", "irmao", "verao", "apoio", "doido", "jovem", "cabra", "lider",
+		"mexer", "santo", "etapa", "animo", "balde", "lugar", "bolsa", "forte", "traço", "tigre",
+		"ponte", "gaita", "zebra", "tecer", "trama", "tirar", "prato", "desde", "calor", "couro",
+		"ninho", "reino", "turma", "bruxa", "chave", "horta", "fonte", "milho", "casal", "porta",
+		"sobre", "acaso", "dupla", "resto", "filho", "mesmo", "fumar", "circo", "forma", "facil",
+		"junto", "pista", "azedo", "rezar", "sonho", "pagar", "senha", "mente", "barro", "corte",
+		"chefe", "livro", "grupo", "touro", "dolar", "palha", "virar", "palma", "magro", "moeda",
+		"melao", "fruta", "gordo", "trato", "cacau", "samba", "furia", "vagao", "atlas", "roupa",
+		"febre", "pente", "pulso", "parte", "nosso", "guiar", "ombro", "rodar", "atual", "morte",
+		"praia", "lavar", "afeto", "feira", "entre", "fatia", "pular", "motor", "grama", "jeito",
+		"caixa", "final", "exame", "plano", "peixe", "obter", "sorte",
+	}
+	// sorted Portuguese words that are allowed as guesses, but are never solutions
+	PortugueseAllowedWords = []string{
+		"bolas", "casas", "dedos", "ervas", "gatos", "hinos", "meias", "mesas", "olhos", "patos",
+		"rimas", "rosas", "unhas", "vacas",
+	}
+)
@@ -2,8 +2,15 @@ package words
 
 import (
 	"fmt"
-	"sort"
 	"time"
+	"unicode/utf8"
+)
+
+// the supported word lengths. the original Wordle uses five letter words
+const (
+	MinLength     = 4
+	MaxLength     = 8
+	DefaultLength = 5
 )
 
 var (
@@ -11,6 +18,24 @@ var (
 	oneDay    time.Duration = time.Hour * 24
 )
 
+// the word lists for each supported word length
+var (
+	solutionsByLength = map[int][]string{
+		4: FourLetterSolutions,
+		5: Solutions,
+		6: SixLetterSolutions,
+		7: SevenLetterSolutions,
+		8: EightLetterSolutions,
+	}
+	allowedByLength = map[int][]string{
+		4: FourLetterAllowedWords,
+		5: AllowedWords,
+		6: SixLetterAllowedWords,
+		7: SevenLetterAllowedWords,
+		8: EightLetterAllowedWords,
+	}
+)
+
 // WordOfTheDay TBD
 func WordOfTheDay(date time.Time) (string, error) {
 	if date.Before(startDate) {
@@ -25,12 +50,65 @@ func WordOfTheDay(date time.Time) (string, error) {
 // in order to get the derived index to look up.
 // Example:
 // To get Wordle 1, the solution is found at solutions[0]
+// The solutions come from the active five letter English word bank, see UseWordBank.
 func GetSpecificWordleSolution(num int) (string, error) {
+	return GetSolution(DefaultLanguage, DefaultLength, num)
+}
+
+// GetSolution works like GetSpecificWordleSolution, but for puzzles in the language
+// with words of the given length. The other languages and lengths have far fewer
+// solutions than there have been days of Wordle, so their puzzle numbers wrap around
+// to the start of the list, which lets every one of them have a daily puzzle.
+func GetSolution(language string, length, num int) (string, error) {
+	lang, sols, err := solutions(language, length)
+	if err != nil {
+		return "", err
+	}
 	idx := num - 1
-	if idx < 0 || idx >= len(Solutions) {
+	if idx >= 0 && len(sols) > 0 && !(lang.Code == DefaultLanguage && length == DefaultLength) {
+		idx %= len(sols)
+	}
+	if idx < 0 || idx >= len(sols) {
 		return "", fmt.Errorf("input index %d is invalid", idx)
 	}
-	return Solutions[idx], nil
+	return sols[idx], nil
+}
+
+// PuzzleCount returns the number of distinct puzzles in the language with words of
+// the given length, which are numbered from 1.
+func PuzzleCount(language string, length int) (int, error) {
+	_, sols, err := solutions(language, length)
+	return len(sols), err
+}
+
+// SolutionsFor returns the solutions for puzzles in the language with words of the
+// given length, in puzzle order. The list belongs to the word bank, so it must not be
+// modified.
+func SolutionsFor(language string, length int) ([]string, error) {
+	_, sols, err := solutions(language, length)
+	return sols, err
+}
+
+// solutions looks up the solutions for puzzles in the language with words of the
+// given length, in puzzle order.
+func solutions(language string, length int) (Language, []string, error) {
+	lang, err := GetLanguage(language)
+	if err != nil {
+		return Language{}, nil, err
+	}
+	if !IsValidLength(length) {
+		return Language{}, nil, fmt.Errorf("words must be between %d and %d letters long, not %d", MinLength, MaxLength, length)
+	}
+	bank, ok := GetWordBank(lang.Code, length)
+	if !ok {
+		return Language{}, nil, fmt.Errorf("there are no %d letter words in %s", length, lang.Name)
+	}
+	return lang, bank.Solutions(), nil
+}
+
+// IsValidLength returns whether there are puzzles with words of the given length.
+func IsValidLength(length int) bool {
+	return length >= MinLength && length <= MaxLength
 }
 
 // DetermineWordForDay uses the start date of 06/19/2021 as a reference point
@@ -41,19 +119,16 @@ func DetermineWordForDay(date time.Time) int {
 }
 
 // IsGuessValid takes an input string and checks if the string is an allowed guess
-// input by checking it against the list of allowed words. This performs a binary search
-// for at least some nomimal optimizations
+// input by checking it against the active English word bank for its length, see UseWordBank.
+// Note that this doesn't know the length of the puzzle being played, so callers need
+// to check that the guess is the same length as the solution.
 func IsGuessValid(s string) bool {
-	sols := GetSortedSolutions()
-	idx := sort.Search(len(sols), func(i int) bool {
-		return i < len(sols) && sols[i] >= s
-	})
-	found := idx < len(sols) && sols[idx] == s
-	if found {
-		return true
-	}
-	idx = sort.Search(len(AllowedWords), func(i int) bool {
-		return i < len(AllowedWords) && AllowedWords[i] >= s
-	})
-	return idx < len(AllowedWords) && AllowedWords[idx] == s
+	return IsGuessValidIn(DefaultLanguage, s)
+}
+
+// IsGuessValidIn works like IsGuessValid, but checks the guess against the word
+// banks for the given language.
+func IsGuessValidIn(language, s string) bool {
+	bank, ok := GetWordBank(language, utf8.RuneCountInString(s))
+	return ok && bank.IsAllowed(s)
 }
@@ -1,6 +1,7 @@
 package words
 
 import (
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -19,6 +20,122 @@ func TestIsGuessValidDoesNotFindWord(t *testing.T) {
 	assert.False(t, IsGuessValid(guess))
 }
 
+func TestIsGuessValidOtherLengths(t *testing.T) {
+	assert.True(t, IsGuessValid("able"))
+	assert.True(t, IsGuessValid("acne"))
+	assert.True(t, IsGuessValid("garden"))
+	assert.True(t, IsGuessValid("village"))
+	assert.True(t, IsGuessValid("absolute"))
+	assert.False(t, IsGuessValid("zzzzzz"))
+	assert.False(t, IsGuessValid("abc"))
+	assert.False(t, IsGuessValid("absolutely"))
+}
+
+func TestGetSolution(t *testing.T) {
+	actual, err := GetSolution(DefaultLanguage, DefaultLength, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, Solutions[4], actual)
+	_, err = GetSolution(DefaultLanguage, DefaultLength, len(Solutions)+1)
+	assert.Error(t, err)
+
+	actual, err = GetSolution(DefaultLanguage, 6, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, SixLetterSolutions[0], actual)
+	// the other lengths wrap around, so every day has a puzzle
+	actual, err = GetSolution(DefaultLanguage, 6, len(SixLetterSolutions)+1)
+	assert.NoError(t, err)
+	assert.Equal(t, SixLetterSolutions[0], actual)
+
+	_, err = GetSolution(DefaultLanguage, 6, 0)
+	assert.Error(t, err)
+	_, err = GetSolution(DefaultLanguage, 3, 1)
+	assert.EqualError(t, err, "words must be between 4 and 8 letters long, not 3")
+	assert.False(t, IsValidLength(9))
+	assert.True(t, IsValidLength(MinLength))
+	assert.True(t, IsValidLength(MaxLength))
+}
+
+func TestGetSolutionLanguages(t *testing.T) {
+	actual, err := GetSolution(Spanish.Code, DefaultLength, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, SpanishSolutions[0], actual)
+	// the other languages wrap around too
+	actual, err = GetSolution(German.Code, DefaultLength, len(GermanSolutions)+1)
+	assert.NoError(t, err)
+	assert.Equal(t, GermanSolutions[0], actual)
+
+	_, err = GetSolution(Portuguese.Code, 6, 1)
+	assert.EqualError(t, err, "there are no 6 letter words in Português")
+	_, err = GetSolution("xx", DefaultLength, 1)
+	assert.EqualError(t, err, "'xx' is not a supported language")
+
+	assert.True(t, IsGuessValidIn(Spanish.Code, "señal"))
+	assert.True(t, IsGuessValidIn(German.Code, "glück"))
+	assert.True(t, IsGuessValidIn(Portuguese.Code, "braço"))
+	assert.False(t, IsGuessValidIn(Spanish.Code, "hello"))
+	assert.False(t, IsGuessValid("señal"))
+}
+
+func TestPuzzleCount(t *testing.T) {
+	n, err := PuzzleCount(DefaultLanguage, DefaultLength)
+	assert.NoError(t, err)
+	assert.Equal(t, len(Solutions), n)
+	n, err = PuzzleCount(German.Code, DefaultLength)
+	assert.NoError(t, err)
+	assert.Equal(t, len(GermanSolutions), n)
+
+	_, err = PuzzleCount(DefaultLanguage, 9)
+	assert.EqualError(t, err, "words must be between 4 and 8 letters long, not 9")
+	_, err = PuzzleCount(Spanish.Code, 4)
+	assert.EqualError(t, err, "there are no 4 letter words in Español")
+}
+
+func TestSolutionsFor(t *testing.T) {
+	sols, err := SolutionsFor(DefaultLanguage, DefaultLength)
+	assert.NoError(t, err)
+	assert.Equal(t, Solutions, sols)
+	_, err = SolutionsFor("xx", DefaultLength)
+	assert.Error(t, err)
+}
+
+func TestGetLanguage(t *testing.T) {
+	lang, err := GetLanguage("")
+	assert.NoError(t, err)
+	assert.Equal(t, English, lang)
+	lang, err = GetLanguage("es")
+	assert.NoError(t, err)
+	assert.Equal(t, Spanish, lang)
+	assert.True(t, lang.HasLetter('ñ'))
+	assert.False(t, English.HasLetter('ñ'))
+}
+
+func TestWordBanks(t *testing.T) {
+	for length := MinLength; length <= MaxLength; length++ {
+		seen := make(map[string]bool)
+		for _, w := range append(append([]string{}, solutionsByLength[length]...), allowedByLength[length]...) {
+			assert.Len(t, w, length, "%s should have %d letters", w, length)
+			assert.False(t, seen[w], "%s is listed more than once", w)
+			seen[w] = true
+		}
+		assert.True(t, sort.StringsAreSorted(allowedByLength[length]), "the allowed %d letter words should be sorted", length)
+	}
+}
+
+func TestLanguageWordBanks(t *testing.T) {
+	lists := map[string][2][]string{
+		Spanish.Code:    {SpanishSolutions, SpanishAllowedWords},
+		German.Code:     {GermanSolutions, GermanAllowedWords},
+		Portuguese.Code: {PortugueseSolutions, PortugueseAllowedWords},
+	}
+	for lang, list := range lists {
+		seen := make(map[string]bool)
+		for _, w := range append(append([]string{}, list[0]...), list[1]...) {
+			assert.False(t, seen[w], "%s is listed more than once in '%s'", w, lang)
+			seen[w] = true
+		}
+	}
+}
+
 func TestDetermineWordForDay(t *testing.T) {
 	d := startDate.AddDate(0, 0, 2)
 	assert.Equal(t, 2, DetermineWordForDay(d))